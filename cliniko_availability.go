@@ -0,0 +1,234 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// availabilityWindowDays is the number of days requested at once,
+// the API accepts at most 7 days between from and to
+const availabilityWindowDays = 7
+
+// DefaultAvailabilityConcurrency is the number of availability
+// requests SearchAvailability sends at the same time
+const DefaultAvailabilityConcurrency = 4
+
+// AvailabilityQuery selects the available times to search for.
+// Every combination of the given businesses, practitioners and
+// appointment types is searched.
+type AvailabilityQuery struct {
+	// From and To are the first and last day searched, taken
+	// in their location, the range may be longer than 7 days
+	From time.Time
+	To   time.Time
+
+	BusinessIds        []BusinessId
+	PractitionerIds    []PractitionerId
+	AppointmentTypeIds []AppointmentTypeId
+
+	// Concurrency is the number of requests sent at the same
+	// time, DefaultAvailabilityConcurrency is used if it is 0
+	Concurrency int
+}
+
+// AvailableSlot is an available appointment start
+// together with the combination it was found for
+type AvailableSlot struct {
+	AppointmentStart  time.Time
+	BusinessId        BusinessId
+	PractitionerId    PractitionerId
+	AppointmentTypeId AppointmentTypeId
+}
+
+// availabilityRequest is a single call of GetAllAvailableTimesGet
+type availabilityRequest struct {
+	businessId        BusinessId
+	practitionerId    PractitionerId
+	appointmentTypeId AppointmentTypeId
+	from              time.Time
+	to                time.Time
+}
+
+// SearchAvailability returns the available times of all
+// combinations in the query sorted by start. The range is split
+// into windows of 7 days which are requested concurrently.
+// Combinations the API does not know, e.g. a practitioner that
+// does not work at a business, have no available times. If the
+// API knows none of them, e.g. because of a mistyped id, the
+// error is ErrNotFound rather than an empty result.
+func (c *ClinikoClient) SearchAvailability(
+	ctx context.Context,
+	query AvailabilityQuery,
+	reqEditors ...RequestEditorFn,
+) (
+	[]AvailableSlot, error,
+) {
+	from, to := truncateDay(query.From), truncateDay(query.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf(
+			"cliniko: availability search ends %s before it starts %s",
+			to.Format("2006-01-02"),
+			from.Format("2006-01-02"),
+		)
+	}
+
+	var requests []availabilityRequest
+	for _, businessId := range query.BusinessIds {
+		for _, practitionerId := range query.PractitionerIds {
+			for _, appointmentTypeId := range query.AppointmentTypeIds {
+				for start := from; !start.After(to); start = start.AddDate(0, 0, availabilityWindowDays) {
+					end := start.AddDate(0, 0, availabilityWindowDays-1)
+					if end.After(to) {
+						end = to
+					}
+
+					requests = append(requests, availabilityRequest{
+						businessId:        businessId,
+						practitionerId:    practitionerId,
+						appointmentTypeId: appointmentTypeId,
+						from:              start,
+						to:                end,
+					})
+				}
+			}
+		}
+	}
+
+	concurrency := query.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultAvailabilityConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pending := make(chan availabilityRequest)
+	go func() {
+		defer close(pending)
+		for _, request := range requests {
+			select {
+			case pending <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		slots    []AvailableSlot
+		firstErr error
+		notFound []error
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for request := range pending {
+				found, err := c.availableSlots(ctx, request, reqEditors)
+
+				mu.Lock()
+				switch {
+				case errors.Is(err, ErrNotFound):
+					notFound = append(notFound, err)
+				case err != nil && firstErr == nil:
+					firstErr = err
+					cancel()
+				}
+				slots = append(slots, found...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if len(requests) > 0 && len(notFound) == len(requests) {
+		return nil, notFound[0]
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		a, b := slots[i], slots[j]
+		switch {
+		case !a.AppointmentStart.Equal(b.AppointmentStart):
+			return a.AppointmentStart.Before(b.AppointmentStart)
+		case a.BusinessId != b.BusinessId:
+			return compareIds(string(a.BusinessId), string(b.BusinessId)) < 0
+		case a.PractitionerId != b.PractitionerId:
+			return compareIds(string(a.PractitionerId), string(b.PractitionerId)) < 0
+		}
+		return compareIds(string(a.AppointmentTypeId), string(b.AppointmentTypeId)) < 0
+	})
+	return slots, nil
+}
+
+// availableSlots reads all pages of available times of one window
+func (c *ClinikoClient) availableSlots(
+	ctx context.Context,
+	request availabilityRequest,
+	reqEditors []RequestEditorFn,
+) (
+	[]AvailableSlot, error,
+) {
+	perPage := DefaultSyncPageSize
+	params := &GetAllAvailableTimesGetParams{
+		From:    openapi_types.Date{Time: request.from},
+		To:      openapi_types.Date{Time: request.to},
+		PerPage: &perPage,
+	}
+
+	times, err := Paginate[AvailableTime](ctx, c, "available_times",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.GetAllAvailableTimesGet(
+				ctx,
+				string(request.businessId),
+				string(request.practitionerId),
+				string(request.appointmentTypeId),
+				params,
+				reqEditors...)
+		},
+		reqEditors...).All()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cliniko: available times of business %s, practitioner %s and appointment type %s: %w",
+			request.businessId,
+			request.practitionerId,
+			request.appointmentTypeId,
+			err,
+		)
+	}
+
+	slots := make([]AvailableSlot, 0, len(times))
+	for _, available := range times {
+		if available.AppointmentStart == nil {
+			continue
+		}
+
+		slots = append(slots, AvailableSlot{
+			AppointmentStart:  *available.AppointmentStart,
+			BusinessId:        request.businessId,
+			PractitionerId:    request.practitionerId,
+			AppointmentTypeId: request.appointmentTypeId,
+		})
+	}
+	return slots, nil
+}
+
+// truncateDay returns midnight of the day of t in its location
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,71 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// TestSearchAvailabilityNotFound searches combinations the fake
+// server does not know, they are only an error if all of them are
+func TestSearchAvailabilityNotFound(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	ids := map[string]string{}
+	for name, record := range map[string]map[string]any{
+		"businesses":        {"business_name": "Clinic"},
+		"practitioners":     {"first_name": "Ann", "last_name": "Smith"},
+		"appointment_types": {"name": "Consult", "duration_in_minutes": 30},
+	} {
+		if ids[name], err = srv.Seed(name, record); err != nil {
+			t.Fatalf("Seed %s: %v", name, err)
+		}
+	}
+	startsAt := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	srv.AddAvailableTimes(ids["businesses"], ids["practitioners"], ids["appointment_types"], startsAt)
+
+	business := cliniko.BusinessId(ids["businesses"])
+	practitioner := cliniko.PractitionerId(ids["practitioners"])
+	appointmentType := cliniko.AppointmentTypeId(ids["appointment_types"])
+
+	tests := []struct {
+		name          string
+		practitioners []cliniko.PractitionerId
+		wantSlots     int
+		wantErr       error
+	}{
+		{"known", []cliniko.PractitionerId{practitioner}, 1, nil},
+		{"mistyped", []cliniko.PractitionerId{"999"}, 0, cliniko.ErrNotFound},
+		{"known and mistyped", []cliniko.PractitionerId{"999", practitioner}, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots, err := client.SearchAvailability(context.Background(), cliniko.AvailabilityQuery{
+				From:               startsAt,
+				To:                 startsAt.AddDate(0, 0, 10),
+				BusinessIds:        []cliniko.BusinessId{business},
+				PractitionerIds:    tt.practitioners,
+				AppointmentTypeIds: []cliniko.AppointmentTypeId{appointmentType},
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("SearchAvailability error = %v, want %v", err, tt.wantErr)
+			}
+			if len(slots) != tt.wantSlots {
+				t.Errorf("SearchAvailability returned %d slots, want %d", len(slots), tt.wantSlots)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultConflictNote is the cancellation note of appointments
+// BookAppointment cancels because of a double booking
+const DefaultConflictNote = "Cancelled automatically, the slot was booked concurrently"
+
+var (
+	// ErrSlotUnavailable is returned by BookAppointment
+	// for slots that are not offered as available time
+	ErrSlotUnavailable = errors.New("cliniko: slot is not available")
+	// ErrDoubleBooked is returned by BookAppointment when the created
+	// appointment conflicts with a booking that was created before it
+	ErrDoubleBooked = errors.New("cliniko: appointment conflicts with another booking")
+)
+
+// AppointmentBooking describes the appointment BookAppointment creates
+type AppointmentBooking struct {
+	PatientId         PatientId
+	PractitionerId    PractitionerId
+	BusinessId        BusinessId
+	AppointmentTypeId AppointmentTypeId
+	StartsAt          time.Time
+
+	// PatientCaseId and Notes are optional
+	PatientCaseId *PatientCaseId
+	Notes         *string
+
+	// Location is the time zone of the business, it determines
+	// the day that is checked for availability and is read
+	// with BusinessTimeZone if nil
+	Location *time.Location
+	// ConflictNote is the cancellation note of a double
+	// booking, defaults to DefaultConflictNote
+	ConflictNote string
+}
+
+// BookingResult is the outcome of BookAppointment
+type BookingResult struct {
+	// Appointment is the created appointment, after
+	// a rollback it is the cancelled appointment
+	Appointment *IndividualAppointment
+	// Conflict is set if the appointment
+	// conflicted with another booking
+	Conflict bool
+	// RolledBack is set if the conflicting
+	// appointment has been cancelled
+	RolledBack bool
+}
+
+// BookAppointment books a patient into an available slot. It
+// checks that the slot is offered as available time, creates the
+// appointment and then asks the API for conflicts. If another
+// booking of the practitioner that was created before the new
+// appointment overlaps it, the new appointment is cancelled with
+// reason "Other" and the error is ErrDoubleBooked, together with
+// the error of the cancel request if that failed. Conflicts with
+// bookings created later are left to those bookings to resolve,
+// the result has Conflict set and the appointment is kept, so of
+// two requests racing for a slot only the later one rolls back.
+// If the order of the bookings can not be told the appointment is
+// kept and returned together with the error.
+// Slots that are not offered return ErrSlotUnavailable without
+// creating anything.
+func (c *ClinikoClient) BookAppointment(
+	ctx context.Context,
+	booking AppointmentBooking,
+	reqEditors ...RequestEditorFn,
+) (
+	*BookingResult, error,
+) {
+	location := booking.Location
+	if location == nil {
+		var err error
+		location, err = c.BusinessTimeZone(ctx, booking.BusinessId, reqEditors...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	startsAt := booking.StartsAt.In(location)
+	slots, err := c.SearchAvailability(ctx, AvailabilityQuery{
+		From:               startsAt,
+		To:                 startsAt,
+		BusinessIds:        []BusinessId{booking.BusinessId},
+		PractitionerIds:    []PractitionerId{booking.PractitionerId},
+		AppointmentTypeIds: []AppointmentTypeId{booking.AppointmentTypeId},
+	}, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	available := false
+	for _, slot := range slots {
+		if slot.AppointmentStart.Equal(startsAt) {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return nil, fmt.Errorf("%w: %s", ErrSlotUnavailable, startsAt.Format(time.RFC3339))
+	}
+
+	body := CreateIndividualAppointmentPostJSONRequestBody{
+		PatientId:         stringPointer(string(booking.PatientId)),
+		PractitionerId:    stringPointer(string(booking.PractitionerId)),
+		BusinessId:        stringPointer(string(booking.BusinessId)),
+		AppointmentTypeId: stringPointer(string(booking.AppointmentTypeId)),
+		StartsAt:          &startsAt,
+		Notes:             booking.Notes,
+	}
+	if booking.PatientCaseId != nil {
+		body.PatientCaseId = stringPointer(string(*booking.PatientCaseId))
+	}
+
+	created, err := c.CreateIndividualAppointmentPostWithResponse(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if created.JSON201 == nil || created.JSON201.Id == nil {
+		return nil,
+			unsuccessfulResponse(
+				"create individual appointment request was unsuccessful",
+				created.HTTPResponse,
+				created.Body,
+			)
+	}
+
+	result := &BookingResult{Appointment: created.JSON201}
+	id := *created.JSON201.Id
+
+	conflicts, err := c.GetIndividualAppointmentConflictsGetWithResponse(ctx, id, reqEditors...)
+	if err != nil {
+		return result, err
+	}
+
+	if conflicts.JSON200 == nil {
+		return result,
+			unsuccessfulResponse(
+				"get individual appointment conflicts request was unsuccessful",
+				conflicts.HTTPResponse,
+				conflicts.Body,
+			)
+	}
+
+	if conflicts.JSON200.Conflicts == nil ||
+		conflicts.JSON200.Conflicts.Exist == nil ||
+		!*conflicts.JSON200.Conflicts.Exist {
+		return result, nil
+	}
+	result.Conflict = true
+
+	later, err := c.bookedBefore(ctx, created.JSON201, booking.PractitionerId, reqEditors...)
+	if err != nil {
+		return result, err
+	}
+	if !later {
+		return result, nil
+	}
+
+	note := booking.ConflictNote
+	if note == "" {
+		note = DefaultConflictNote
+	}
+	err = c.CancelIndividualAppointment(ctx, IndividualAppointmentId(id), IndividualAppointmentCancellation{
+		Reason: IndividualAppointmentCancelCancellationReasonOther,
+		Note:   note,
+	}, reqEditors...)
+	if err != nil {
+		return result, errors.Join(
+			ErrDoubleBooked,
+			fmt.Errorf("cancel individual appointment %s: %w", id, err),
+		)
+	}
+	result.RolledBack = true
+
+	if cancelled, err := c.GetIndividualAppointment(ctx, IndividualAppointmentId(id), reqEditors...); err == nil {
+		result.Appointment = cancelled
+	}
+	return result, ErrDoubleBooked
+}
+
+// conflictingBooking holds the fields bookedBefore reads of a booking
+type conflictingBooking struct {
+	Id          string     `json:"id"`
+	CreatedAt   *time.Time `json:"created_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+}
+
+// bookedBefore reports whether another active booking of the
+// practitioner that overlaps the appointment was created before
+// it. Bookings created in the same second are ordered by id. An
+// appointment without times or a practitioner id that is not
+// numeric is an error, as the order can not be told.
+func (c *ClinikoClient) bookedBefore(
+	ctx context.Context,
+	appointment *IndividualAppointment,
+	practitionerId PractitionerId,
+	reqEditors ...RequestEditorFn,
+) (
+	bool, error,
+) {
+	practitioner, err := strconv.ParseInt(string(practitionerId), 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("cliniko: practitioner id %q is not numeric", practitionerId)
+	}
+	if appointment.CreatedAt == nil ||
+		appointment.StartsAt == nil ||
+		appointment.EndsAt == nil {
+		return false, fmt.Errorf(
+			"cliniko: individual appointment %s has no created_at, starts_at or ends_at",
+			*appointment.Id,
+		)
+	}
+
+	params := &ListBookingsGetParams{
+		Q: Filters(
+			ListBookingsGetFilters.PractitionerId.Equal(practitioner),
+			ListBookingsGetFilters.StartsAt.Less(*appointment.EndsAt),
+			ListBookingsGetFilters.EndsAt.Greater(*appointment.StartsAt),
+		),
+	}
+	bookings := Paginate[conflictingBooking](ctx, c, "bookings",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListBookingsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+
+	id := *appointment.Id
+	for bookings.Next() {
+		other := bookings.Item()
+		if other.Id == id || other.CancelledAt != nil || other.CreatedAt == nil {
+			continue
+		}
+
+		if other.CreatedAt.Before(*appointment.CreatedAt) ||
+			other.CreatedAt.Equal(*appointment.CreatedAt) && compareIds(other.Id, id) < 0 {
+			return true, nil
+		}
+	}
+	return false, bookings.Err()
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
@@ -0,0 +1,190 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// TestBookAppointmentRace books a slot while another booking for
+// it is created concurrently, only the later booking rolls back
+func TestBookAppointmentRace(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	startsAt := now.Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		practitionerId string
+		otherPatient   bool
+		otherCreated   time.Time
+		wantErr        error
+		wantConflict   bool
+		wantRollback   bool
+	}{
+		{
+			name:         "other booked first",
+			otherCreated: now.Add(-time.Second),
+			wantErr:      cliniko.ErrDoubleBooked,
+			wantConflict: true,
+			wantRollback: true,
+		},
+		{
+			name:         "other booked later",
+			otherCreated: now.Add(time.Second),
+			wantConflict: true,
+		},
+		{
+			name:         "other patient booked first",
+			otherPatient: true,
+			otherCreated: now.Add(-time.Second),
+			wantErr:      cliniko.ErrDoubleBooked,
+			wantConflict: true,
+			wantRollback: true,
+		},
+		{
+			name:         "other patient booked later",
+			otherPatient: true,
+			otherCreated: now.Add(time.Second),
+			wantConflict: true,
+		},
+		{
+			name:           "practitioner id not numeric",
+			practitionerId: "ann",
+			otherCreated:   now.Add(-time.Second),
+			wantConflict:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := clinikotest.NewServer(clinikotest.WithClock(func() time.Time { return now }))
+			defer srv.Close()
+			client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+			if err != nil {
+				t.Fatalf("NewClinikoClient: %v", err)
+			}
+
+			practitioner := map[string]any{"first_name": "Ann", "last_name": "Smith"}
+			if tt.practitionerId != "" {
+				practitioner["id"] = tt.practitionerId
+			}
+			ids := map[string]string{}
+			for name, record := range map[string]map[string]any{
+				"businesses":        {"business_name": "Clinic"},
+				"practitioners":     practitioner,
+				"appointment_types": {"name": "Consult", "duration_in_minutes": 30},
+				"patients":          {"first_name": "Jane", "last_name": "Doe"},
+			} {
+				if ids[name], err = srv.Seed(name, record); err != nil {
+					t.Fatalf("Seed %s: %v", name, err)
+				}
+			}
+			otherPatient := ids["patients"]
+			if tt.otherPatient {
+				otherPatient, err = srv.Seed("patients", map[string]any{"first_name": "John", "last_name": "Roe"})
+				if err != nil {
+					t.Fatalf("Seed patients: %v", err)
+				}
+			}
+			srv.AddAvailableTimes(ids["businesses"], ids["practitioners"], ids["appointment_types"], startsAt)
+
+			// the other booking is stored right before the
+			// appointment BookAppointment creates
+			bookOther := func(ctx context.Context, req *http.Request) error {
+				if req.Method != http.MethodPost {
+					return nil
+				}
+				_, err := srv.Seed("individual_appointments", map[string]any{
+					"patient_id":          otherPatient,
+					"practitioner_id":     ids["practitioners"],
+					"business_id":         ids["businesses"],
+					"appointment_type_id": ids["appointment_types"],
+					"starts_at":           startsAt.Format(time.RFC3339),
+					"ends_at":             startsAt.Add(30 * time.Minute).Format(time.RFC3339),
+					"cancelled_at":        nil,
+					"created_at":          tt.otherCreated.Format(time.RFC3339),
+				})
+				return err
+			}
+
+			result, err := client.BookAppointment(context.Background(), cliniko.AppointmentBooking{
+				PatientId:         cliniko.PatientId(ids["patients"]),
+				PractitionerId:    cliniko.PractitionerId(ids["practitioners"]),
+				BusinessId:        cliniko.BusinessId(ids["businesses"]),
+				AppointmentTypeId: cliniko.AppointmentTypeId(ids["appointment_types"]),
+				StartsAt:          startsAt,
+				Location:          time.UTC,
+			}, bookOther)
+
+			switch {
+			case tt.practitionerId != "":
+				if err == nil || errors.Is(err, cliniko.ErrDoubleBooked) {
+					t.Errorf("err = %v, want an error about the practitioner id", err)
+				}
+			case !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil):
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if result == nil {
+				t.Fatal("no result")
+			}
+			if result.Conflict != tt.wantConflict {
+				t.Errorf("conflict = %v, want %v", result.Conflict, tt.wantConflict)
+			}
+			if result.RolledBack != tt.wantRollback {
+				t.Errorf("rolled back = %v, want %v", result.RolledBack, tt.wantRollback)
+			}
+			cancelled := result.Appointment != nil && result.Appointment.CancelledAt != nil
+			if cancelled != tt.wantRollback {
+				t.Errorf("appointment cancelled = %v, want %v", cancelled, tt.wantRollback)
+			}
+		})
+	}
+}
+
+// TestBookAppointmentNotFound books with a mistyped id, which is
+// reported as such rather than as an unavailable slot
+func TestBookAppointmentNotFound(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	ids := map[string]string{}
+	for name, record := range map[string]map[string]any{
+		"businesses":        {"business_name": "Clinic"},
+		"appointment_types": {"name": "Consult", "duration_in_minutes": 30},
+		"patients":          {"first_name": "Jane", "last_name": "Doe"},
+	} {
+		if ids[name], err = srv.Seed(name, record); err != nil {
+			t.Fatalf("Seed %s: %v", name, err)
+		}
+	}
+
+	result, err := client.BookAppointment(context.Background(), cliniko.AppointmentBooking{
+		PatientId:         cliniko.PatientId(ids["patients"]),
+		PractitionerId:    "999",
+		BusinessId:        cliniko.BusinessId(ids["businesses"]),
+		AppointmentTypeId: cliniko.AppointmentTypeId(ids["appointment_types"]),
+		StartsAt:          time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC),
+		Location:          time.UTC,
+	})
+	if !errors.Is(err, cliniko.ErrNotFound) || errors.Is(err, cliniko.ErrSlotUnavailable) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+	if result != nil {
+		t.Errorf("result = %+v, want nil", result)
+	}
+	if records := srv.Records("individual_appointments"); len(records) != 0 {
+		t.Errorf("%d appointments created, want none", len(records))
+	}
+}
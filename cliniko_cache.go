@@ -0,0 +1,382 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is the time reference data is cached for
+// by the TTLs returned from DefaultCacheTTLs
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultCacheEntries is the capacity of an LRUCacheStore
+// created with a capacity of 0
+const DefaultCacheEntries = 1000
+
+// DefaultCacheTTLs returns the TTLs of the reference data
+// that rarely changes, by the collection in the request path
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"appointment_types":     DefaultCacheTTL,
+		"billable_items":        DefaultCacheTTL,
+		"businesses":            DefaultCacheTTL,
+		"concession_types":      DefaultCacheTTL,
+		"practitioners":         DefaultCacheTTL,
+		"referral_source_types": DefaultCacheTTL,
+		"settings":              DefaultCacheTTL,
+		"taxes":                 DefaultCacheTTL,
+	}
+}
+
+// CachedResponse is a response stored by a CacheDoer.
+// All fields are exported so that stores can serialize it.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Expires is the time until which the response
+	// is served without asking the API
+	Expires time.Time
+}
+
+// CacheStore stores the responses of a CacheDoer. Keys start
+// with the collection followed by "|", so all responses of a
+// collection can be removed with DeletePrefix. Implementations
+// must be safe for concurrent use.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (*CachedResponse, bool, error)
+	Set(ctx context.Context, key string, response *CachedResponse) error
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+type cacheContextKey struct{}
+
+// ContextWithCache enables or disables the cache for
+// all requests made with the returned context
+func ContextWithCache(ctx context.Context, cache bool) context.Context {
+	return context.WithValue(ctx, cacheContextKey{}, cache)
+}
+
+// CacheDoer wraps a HttpRequestDoer and caches successful GET
+// responses of the collections it has a TTL for. Expired
+// responses with an ETag or Last-Modified header are
+// revalidated with a conditional request. Successful POST,
+// PATCH, PUT and DELETE requests, e.g. an archive, drop all
+// cached responses of the collections in their path. Responses
+// to GET requests that were sent before such a request succeeded
+// are returned but not cached, as they may be stale. Only the
+// requests of the same CacheDoer are taken into account.
+//
+//	client, err := NewClinikoClientWithOptions(token, vendor, email,
+//		WithHTTPDoer(NewCacheDoer(
+//			NewRateLimitedDoer(nil, DefaultRequestsPerMinute),
+//			NewLRUCacheStore(0),
+//			DefaultCacheTTLs(),
+//		)))
+type CacheDoer struct {
+	doer  HttpRequestDoer
+	store CacheStore
+	ttls  map[string]time.Duration
+
+	// mu is held for reading while a response is stored and for
+	// writing while a collection is invalidated
+	mu sync.RWMutex
+	// generations counts the invalidations of each collection
+	generations map[string]uint64
+}
+
+// NewCacheDoer creates a CacheDoer that sends requests through
+// doer and caches responses in store for the given TTLs. A nil
+// doer defaults to an http.Client using CheckRedirect.
+func NewCacheDoer(
+	doer HttpRequestDoer,
+	store CacheStore,
+	ttls map[string]time.Duration,
+) *CacheDoer {
+	if doer == nil {
+		doer = newHTTPClient()
+	}
+
+	return &CacheDoer{
+		doer:        doer,
+		store:       store,
+		ttls:        ttls,
+		generations: map[string]uint64{},
+	}
+}
+
+// Unwrap returns the Doer requests are sent through
+func (d *CacheDoer) Unwrap() HttpRequestDoer {
+	return d.doer
+}
+
+// Do serves the request from the cache if possible
+// and implements HttpRequestDoer
+func (d *CacheDoer) Do(req *http.Request) (*http.Response, error) {
+	if enabled, ok := req.Context().Value(cacheContextKey{}).(bool); ok && !enabled {
+		return d.doer.Do(req)
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return d.invalidate(req)
+	}
+
+	collection := cacheCollection(req.URL.Path)
+	ttl, ok := d.ttls[collection]
+	if req.Method != http.MethodGet || !ok || ttl <= 0 || req.Header.Get("Range") != "" {
+		return d.doer.Do(req)
+	}
+
+	ctx := req.Context()
+	key := cacheKey(collection, req)
+	cached, found, err := d.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && time.Now().Before(cached.Expires) {
+		return cached.response(req), nil
+	}
+
+	if found {
+		req = revalidate(req, cached)
+	}
+
+	generation := d.generation(collection)
+
+	rsp, err := d.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && rsp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		_ = rsp.Body.Close()
+
+		cached.Expires = time.Now().Add(ttl)
+		if err := d.set(ctx, collection, generation, key, cached); err != nil {
+			return nil, err
+		}
+		return cached.response(req), nil
+	}
+
+	if rsp.StatusCode != http.StatusOK ||
+		strings.Contains(rsp.Header.Get("Cache-Control"), "no-store") {
+		return rsp, nil
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	_ = rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = d.set(ctx, collection, generation, key, &CachedResponse{
+		StatusCode: rsp.StatusCode,
+		Header:     rsp.Header.Clone(),
+		Body:       body,
+		Expires:    time.Now().Add(ttl),
+	})
+	return rsp, err
+}
+
+// invalidate sends a modifying request and drops the cached
+// responses of all collections in its path once it succeeded
+func (d *CacheDoer) invalidate(req *http.Request) (*http.Response, error) {
+	rsp, err := d.doer.Do(req)
+	if err != nil || rsp.StatusCode >= http.StatusBadRequest {
+		return rsp, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, segment := range strings.Split(req.URL.Path, "/") {
+		if _, ok := d.ttls[segment]; !ok {
+			continue
+		}
+
+		d.generations[segment]++
+		if err := d.store.DeletePrefix(req.Context(), segment+"|"); err != nil {
+			_ = rsp.Body.Close()
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+// generation returns the number of invalidations of the collection
+func (d *CacheDoer) generation(collection string) uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.generations[collection]
+}
+
+// set stores the response unless the collection was invalidated
+// since generation was read, i.e. while the request was in flight
+func (d *CacheDoer) set(
+	ctx context.Context,
+	collection string,
+	generation uint64,
+	key string,
+	response *CachedResponse,
+) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.generations[collection] != generation {
+		return nil
+	}
+	return d.store.Set(ctx, key, response)
+}
+
+// revalidate returns a conditional request for an expired response
+func revalidate(req *http.Request, cached *CachedResponse) *http.Request {
+	etag := cached.Header.Get("ETag")
+	modified := cached.Header.Get("Last-Modified")
+	if etag == "" && modified == "" {
+		return req
+	}
+
+	req = req.Clone(req.Context())
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if modified != "" {
+		req.Header.Set("If-Modified-Since", modified)
+	}
+	return req
+}
+
+// response returns a new http.Response for the cached one
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(r.StatusCode) + " " + http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// cacheCollection returns the collection a GET request reads,
+// i.e. the last path segment that is not an id. The public
+// settings are cached as part of the settings.
+func cacheCollection(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		if segment == "" || strings.Trim(segment, "0123456789") == "" {
+			continue
+		}
+		if segment == "public" && i > 0 && segments[i-1] == "settings" {
+			return "settings"
+		}
+		return segment
+	}
+	return ""
+}
+
+// cacheKey identifies a response by collection, URL and
+// credentials, so that accounts never share entries
+func cacheKey(collection string, req *http.Request) string {
+	credentials := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return collection + "|" + hex.EncodeToString(credentials[:8]) + "|" + req.URL.String()
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the
+// least recently used response once it is full
+type LRUCacheStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewLRUCacheStore creates an LRUCacheStore holding up to
+// capacity responses, 0 uses DefaultCacheEntries
+func NewLRUCacheStore(capacity int) *LRUCacheStore {
+	if capacity <= 0 {
+		capacity = DefaultCacheEntries
+	}
+
+	return &LRUCacheStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored under key
+func (s *LRUCacheStore) Get(ctx context.Context, key string) (*CachedResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	s.order.MoveToFront(element)
+	copied := *element.Value.(*lruEntry).response
+	return &copied, true, nil
+}
+
+// Set stores the response under key
+func (s *LRUCacheStore) Set(ctx context.Context, key string, response *CachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *response
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*lruEntry).response = &copied
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, response: &copied})
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// DeletePrefix removes all responses whose key starts with prefix
+func (s *LRUCacheStore) DeletePrefix(ctx context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, element := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			s.order.Remove(element)
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// originDoer answers requests with serve and records them
+type originDoer struct {
+	serve func(req *http.Request, count int) (int, http.Header)
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (d *originDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	d.requests = append(d.requests, req)
+	count := len(d.requests)
+	d.mu.Unlock()
+
+	status, header := http.StatusOK, http.Header{}
+	if d.serve != nil {
+		status, header = d.serve(req, count)
+	}
+	body := ""
+	if status == http.StatusOK {
+		body = "response " + strconv.Itoa(count)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (d *originDoer) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.requests)
+}
+
+// cacheGet sends a request through the doer and returns the body
+func cacheGet(t *testing.T, ctx context.Context, doer HttpRequestDoer, method string, path string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, method, "https://api.au1.cliniko.com/v1"+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Basic account")
+	rsp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer rsp.Body.Close()
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rsp.StatusCode, string(body)
+}
+
+// expire makes all responses in the store expired
+func expire(store *LRUCacheStore) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, element := range store.entries {
+		element.Value.(*lruEntry).response.Expires = time.Now().Add(-time.Second)
+	}
+}
+
+func TestCacheDoerTTL(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		path      string
+		expire    bool
+		wantCount int
+	}{
+		{"cached", ctx, "/businesses/1", false, 1},
+		{"public settings", ctx, "/settings/public", false, 1},
+		{"not reference data", ctx, "/patients/1", false, 2},
+		{"disabled by context", ContextWithCache(ctx, false), "/businesses/1", false, 2},
+		{"expired", ctx, "/businesses/1", true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := &originDoer{}
+			store := NewLRUCacheStore(0)
+			doer := NewCacheDoer(origin, store, DefaultCacheTTLs())
+
+			_, first := cacheGet(t, tt.ctx, doer, http.MethodGet, tt.path)
+			if tt.expire {
+				expire(store)
+			}
+			_, second := cacheGet(t, tt.ctx, doer, http.MethodGet, tt.path)
+
+			if origin.count() != tt.wantCount {
+				t.Errorf("%d requests sent, want %d", origin.count(), tt.wantCount)
+			}
+			if cached := tt.wantCount == 1; (first == second) != cached {
+				t.Errorf("bodies %q and %q, want cached %v", first, second, cached)
+			}
+		})
+	}
+}
+
+func TestCacheDoerRevalidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		validator   string
+		value       string
+		conditional string
+		unchanged   bool
+	}{
+		{"etag unchanged", "ETag", `"v1"`, "If-None-Match", true},
+		{"etag changed", "ETag", `"v1"`, "If-None-Match", false},
+		{"last modified unchanged", "Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT", "If-Modified-Since", true},
+		{"last modified changed", "Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT", "If-Modified-Since", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := &originDoer{
+				serve: func(req *http.Request, count int) (int, http.Header) {
+					if tt.unchanged && req.Header.Get(tt.conditional) == tt.value {
+						return http.StatusNotModified, http.Header{}
+					}
+					header := http.Header{}
+					header.Set(tt.validator, tt.value)
+					return http.StatusOK, header
+				},
+			}
+			store := NewLRUCacheStore(0)
+			doer := NewCacheDoer(origin, store, DefaultCacheTTLs())
+			ctx := context.Background()
+
+			_, first := cacheGet(t, ctx, doer, http.MethodGet, "/practitioners")
+			expire(store)
+			status, second := cacheGet(t, ctx, doer, http.MethodGet, "/practitioners")
+			_, third := cacheGet(t, ctx, doer, http.MethodGet, "/practitioners")
+
+			if origin.count() != 2 {
+				t.Fatalf("%d requests sent, want 2", origin.count())
+			}
+			if got := origin.requests[1].Header.Get(tt.conditional); got != tt.value {
+				t.Errorf("%s = %q, want %q", tt.conditional, got, tt.value)
+			}
+			if status != http.StatusOK {
+				t.Errorf("status = %d, want 200", status)
+			}
+			if want := map[bool]string{true: first, false: "response 2"}[tt.unchanged]; second != want || third != want {
+				t.Errorf("bodies %q and %q, want %q", second, third, want)
+			}
+		})
+	}
+}
+
+func TestCacheDoerInvalidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		status    int
+		wantCount int
+	}{
+		{"patch", http.MethodPatch, "/businesses/1", http.StatusOK, 3},
+		{"archive", http.MethodPost, "/businesses/1/archive", http.StatusNoContent, 3},
+		{"other collection", http.MethodPatch, "/patients/1", http.StatusOK, 2},
+		{"failed", http.MethodPatch, "/businesses/1", http.StatusUnprocessableEntity, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := &originDoer{
+				serve: func(req *http.Request, count int) (int, http.Header) {
+					if req.Method != http.MethodGet {
+						return tt.status, http.Header{}
+					}
+					return http.StatusOK, http.Header{}
+				},
+			}
+			doer := NewCacheDoer(origin, NewLRUCacheStore(0), DefaultCacheTTLs())
+			ctx := context.Background()
+
+			cacheGet(t, ctx, doer, http.MethodGet, "/businesses/1")
+			if status, _ := cacheGet(t, ctx, doer, tt.method, tt.path); status != tt.status {
+				t.Fatalf("status = %d, want %d", status, tt.status)
+			}
+			cacheGet(t, ctx, doer, http.MethodGet, "/businesses/1")
+
+			if origin.count() != tt.wantCount {
+				t.Errorf("%d requests sent, want %d", origin.count(), tt.wantCount)
+			}
+		})
+	}
+}
+
+// TestCacheDoerInFlight updates a business while it is read,
+// the response of the read may be stale and is not cached
+func TestCacheDoerInFlight(t *testing.T) {
+	reading := make(chan struct{})
+	updated := make(chan struct{})
+	origin := &originDoer{
+		serve: func(req *http.Request, count int) (int, http.Header) {
+			if req.Method == http.MethodGet && count == 1 {
+				close(reading)
+				<-updated
+			}
+			return http.StatusOK, http.Header{}
+		},
+	}
+	doer := NewCacheDoer(origin, NewLRUCacheStore(0), DefaultCacheTTLs())
+	ctx := context.Background()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.au1.cliniko.com/v1/businesses/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Basic account")
+	done := make(chan string)
+	go func() {
+		var body []byte
+		if rsp, err := doer.Do(req); err == nil {
+			body, _ = io.ReadAll(rsp.Body)
+			_ = rsp.Body.Close()
+		}
+		done <- string(body)
+	}()
+
+	<-reading
+	cacheGet(t, ctx, doer, http.MethodPatch, "/businesses/1")
+	close(updated)
+	if body := <-done; body != "response 1" {
+		t.Errorf("in flight body = %q, want %q", body, "response 1")
+	}
+
+	if _, body := cacheGet(t, ctx, doer, http.MethodGet, "/businesses/1"); body != "response 3" {
+		t.Errorf("body = %q, want the response sent after the update", body)
+	}
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewLRUCacheStore(2)
+	set := func(key string) {
+		if err := store.Set(ctx, key, &CachedResponse{Body: []byte(key)}); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	has := func(key string) bool {
+		response, ok, err := store.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if ok && string(response.Body) != key {
+			t.Errorf("Get(%q) = %q", key, response.Body)
+		}
+		return ok
+	}
+
+	set("taxes|a")
+	set("taxes|b")
+	has("taxes|a")
+	set("businesses|c")
+	if !has("taxes|a") || has("taxes|b") || !has("businesses|c") {
+		t.Errorf("least recently used response not evicted")
+	}
+
+	if err := store.DeletePrefix(ctx, "taxes|"); err != nil {
+		t.Fatalf("DeletePrefix: %v", err)
+	}
+	if has("taxes|a") || !has("businesses|c") {
+		t.Errorf("DeletePrefix removed the wrong responses")
+	}
+}
@@ -0,0 +1,342 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidCancellationReason is returned for cancellation
+// reasons and descriptions the API does not define
+var ErrInvalidCancellationReason = errors.New("cliniko: invalid cancellation reason")
+
+// Valid reports whether the API defines the reason
+func (r AttendeeCancelCancellationReason) Valid() bool {
+	_, ok := attendeeCancellationDescriptions[r]
+	return ok
+}
+
+// Description returns the description the API
+// sends for the reason, e.g. "Feeling Better"
+func (r AttendeeCancelCancellationReason) Description() AttendeeCancellationReasonDescription {
+	return attendeeCancellationDescriptions[r]
+}
+
+func (r AttendeeCancelCancellationReason) String() string {
+	if !r.Valid() {
+		return fmt.Sprintf("AttendeeCancelCancellationReason(%d)", int(r))
+	}
+	return string(r.Description())
+}
+
+// ParseAttendeeCancellationReason returns the reason of a
+// CancellationReasonDescription of an Attendee
+func ParseAttendeeCancellationReason(
+	description AttendeeCancellationReasonDescription,
+) (
+	AttendeeCancelCancellationReason, error,
+) {
+	for reason, known := range attendeeCancellationDescriptions {
+		if known == description {
+			return reason, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidCancellationReason, description)
+}
+
+var attendeeCancellationDescriptions = map[AttendeeCancelCancellationReason]AttendeeCancellationReasonDescription{
+	AttendeeCancelCancellationReasonFeelingBetter:  AttendeeCancellationReasonDescriptionFeelingBetter,
+	AttendeeCancelCancellationReasonConditionWorse: AttendeeCancellationReasonDescriptionConditionWorse,
+	AttendeeCancelCancellationReasonSick:           AttendeeCancellationReasonDescriptionSick,
+	AttendeeCancelCancellationReasonCOVID19Related: AttendeeCancellationReasonDescriptionCOVID19Related,
+	AttendeeCancelCancellationReasonAway:           AttendeeCancellationReasonDescriptionAway,
+	AttendeeCancelCancellationReasonOther:          AttendeeCancellationReasonDescriptionOther,
+	AttendeeCancelCancellationReasonWork:           AttendeeCancellationReasonDescriptionWork,
+}
+
+// Valid reports whether the API defines the reason
+func (r IndividualAppointmentCancelCancellationReason) Valid() bool {
+	_, ok := individualAppointmentCancellationDescriptions[r]
+	return ok
+}
+
+// Description returns the description the API
+// sends for the reason, e.g. "Feeling Better"
+func (r IndividualAppointmentCancelCancellationReason) Description() IndividualAppointmentCancellationReasonDescription {
+	return individualAppointmentCancellationDescriptions[r]
+}
+
+func (r IndividualAppointmentCancelCancellationReason) String() string {
+	if !r.Valid() {
+		return fmt.Sprintf("IndividualAppointmentCancelCancellationReason(%d)", int(r))
+	}
+	return string(r.Description())
+}
+
+// ParseIndividualAppointmentCancellationReason returns the reason
+// of a CancellationReasonDescription of an IndividualAppointment
+func ParseIndividualAppointmentCancellationReason(
+	description IndividualAppointmentCancellationReasonDescription,
+) (
+	IndividualAppointmentCancelCancellationReason, error,
+) {
+	for reason, known := range individualAppointmentCancellationDescriptions {
+		if known == description {
+			return reason, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidCancellationReason, description)
+}
+
+var individualAppointmentCancellationDescriptions = map[IndividualAppointmentCancelCancellationReason]IndividualAppointmentCancellationReasonDescription{
+	IndividualAppointmentCancelCancellationReasonFeelingBetter:  IndividualAppointmentCancellationReasonDescriptionFeelingBetter,
+	IndividualAppointmentCancelCancellationReasonConditionWorse: IndividualAppointmentCancellationReasonDescriptionConditionWorse,
+	IndividualAppointmentCancelCancellationReasonSick:           IndividualAppointmentCancellationReasonDescriptionSick,
+	IndividualAppointmentCancelCancellationReasonCOVID19Related: IndividualAppointmentCancellationReasonDescriptionCOVID19Related,
+	IndividualAppointmentCancelCancellationReasonAway:           IndividualAppointmentCancellationReasonDescriptionAway,
+	IndividualAppointmentCancelCancellationReasonOther:          IndividualAppointmentCancellationReasonDescriptionOther,
+	IndividualAppointmentCancelCancellationReasonWork:           IndividualAppointmentCancellationReasonDescriptionWork,
+}
+
+// CancelReason returns the reason the attendee was cancelled for,
+// read from CancellationReason or else CancellationReasonDescription.
+// It returns false for attendees without a known reason.
+func (a Attendee) CancelReason() (AttendeeCancelCancellationReason, bool) {
+	if a.CancellationReason != nil {
+		reason := AttendeeCancelCancellationReason(*a.CancellationReason)
+		return reason, reason.Valid()
+	}
+	if a.CancellationReasonDescription == nil {
+		return 0, false
+	}
+
+	reason, err := ParseAttendeeCancellationReason(*a.CancellationReasonDescription)
+	return reason, err == nil
+}
+
+// CancelReason returns the reason the appointment was cancelled for,
+// read from CancellationReason or else CancellationReasonDescription.
+// It returns false for appointments without a known reason.
+func (ia IndividualAppointment) CancelReason() (IndividualAppointmentCancelCancellationReason, bool) {
+	if ia.CancellationReason != nil {
+		reason := IndividualAppointmentCancelCancellationReason(*ia.CancellationReason)
+		return reason, reason.Valid()
+	}
+	if ia.CancellationReasonDescription == nil {
+		return 0, false
+	}
+
+	reason, err := ParseIndividualAppointmentCancellationReason(*ia.CancellationReasonDescription)
+	return reason, err == nil
+}
+
+// AttendeeCancellation describes the cancellation of an attendee
+type AttendeeCancellation struct {
+	Reason AttendeeCancelCancellationReason
+	// Note is optional
+	Note string
+	// ApplyToRepeats cancels the attendee in
+	// the repeats of the group appointment too
+	ApplyToRepeats bool
+}
+
+// Body returns the request body of CancelAttendeePatch,
+// or ErrInvalidCancellationReason for unknown reasons
+func (ac AttendeeCancellation) Body() (CancelAttendeePatchJSONRequestBody, error) {
+	if !ac.Reason.Valid() {
+		return CancelAttendeePatchJSONRequestBody{},
+			fmt.Errorf("%w: %d", ErrInvalidCancellationReason, int(ac.Reason))
+	}
+
+	reason := CancelAttendeePatchJSONBodyCancellationReason(ac.Reason)
+	body := CancelAttendeePatchJSONRequestBody{CancellationReason: &reason}
+	if ac.Note != "" {
+		body.CancellationNote = &ac.Note
+	}
+	if ac.ApplyToRepeats {
+		body.ApplyToRepeats = &ac.ApplyToRepeats
+	}
+	return body, nil
+}
+
+// IndividualAppointmentCancellation describes the
+// cancellation of an individual appointment
+type IndividualAppointmentCancellation struct {
+	Reason IndividualAppointmentCancelCancellationReason
+	// Note is optional
+	Note string
+	// ApplyToRepeats cancels the repeats of the appointment too
+	ApplyToRepeats bool
+}
+
+// Body returns the request body of CancelIndividualAppointmentPatch,
+// or ErrInvalidCancellationReason for unknown reasons
+func (iac IndividualAppointmentCancellation) Body() (CancelIndividualAppointmentPatchJSONRequestBody, error) {
+	if !iac.Reason.Valid() {
+		return CancelIndividualAppointmentPatchJSONRequestBody{},
+			fmt.Errorf("%w: %d", ErrInvalidCancellationReason, int(iac.Reason))
+	}
+
+	reason := CancelIndividualAppointmentPatchJSONBodyCancellationReason(iac.Reason)
+	body := CancelIndividualAppointmentPatchJSONRequestBody{CancellationReason: &reason}
+	if iac.Note != "" {
+		body.CancellationNote = &iac.Note
+	}
+	if iac.ApplyToRepeats {
+		body.ApplyToRepeats = &iac.ApplyToRepeats
+	}
+	return body, nil
+}
+
+// CancelAttendee cancels an attendee of a group appointment.
+// Invalid reasons return ErrInvalidCancellationReason
+// without sending a request.
+func (c *ClinikoClient) CancelAttendee(
+	ctx context.Context,
+	id AttendeeId,
+	cancellation AttendeeCancellation,
+	reqEditors ...RequestEditorFn,
+) error {
+	body, err := cancellation.Body()
+	if err != nil {
+		return err
+	}
+
+	_, err = Check(c.CancelAttendeePatchWithResponse(ctx, string(id), body, reqEditors...))
+	return err
+}
+
+// CancelIndividualAppointment cancels an individual appointment.
+// Invalid reasons return ErrInvalidCancellationReason
+// without sending a request.
+func (c *ClinikoClient) CancelIndividualAppointment(
+	ctx context.Context,
+	id IndividualAppointmentId,
+	cancellation IndividualAppointmentCancellation,
+	reqEditors ...RequestEditorFn,
+) error {
+	body, err := cancellation.Body()
+	if err != nil {
+		return err
+	}
+
+	_, err = Check(c.CancelIndividualAppointmentPatchWithResponse(ctx, string(id), body, reqEditors...))
+	return err
+}
+
+// UnspecifiedCancellationReason is the key ByDescription
+// counts cancellations without a known reason under
+const UnspecifiedCancellationReason = "Unspecified"
+
+// CancellationReport counts the cancellations of a period by reason
+type CancellationReport struct {
+	From time.Time
+	To   time.Time
+
+	// IndividualAppointments counts cancelled individual appointments
+	IndividualAppointments map[IndividualAppointmentCancelCancellationReason]int
+	// Attendees counts cancelled attendees of group appointments
+	Attendees map[AttendeeCancelCancellationReason]int
+	// Unspecified counts cancellations without a known reason
+	Unspecified int
+}
+
+// Total returns the number of cancellations in the report
+func (r *CancellationReport) Total() int {
+	total := r.Unspecified
+	for _, count := range r.IndividualAppointments {
+		total += count
+	}
+	for _, count := range r.Attendees {
+		total += count
+	}
+	return total
+}
+
+// ByDescription returns the number of individual appointments and
+// attendees cancelled per reason description, e.g. "Sick". Both
+// share the same reasons, cancellations without a known reason
+// are counted under UnspecifiedCancellationReason.
+func (r *CancellationReport) ByDescription() map[string]int {
+	counts := map[string]int{}
+	for reason, count := range r.IndividualAppointments {
+		counts[string(reason.Description())] += count
+	}
+	for reason, count := range r.Attendees {
+		counts[string(reason.Description())] += count
+	}
+	if r.Unspecified > 0 {
+		counts[UnspecifiedCancellationReason] += r.Unspecified
+	}
+	return counts
+}
+
+// CancellationsBetween reports the individual appointments and
+// attendees cancelled within [from, to) by cancellation reason
+func (c *ClinikoClient) CancellationsBetween(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	reqEditors ...RequestEditorFn,
+) (
+	*CancellationReport, error,
+) {
+	report := &CancellationReport{
+		From:                   from,
+		To:                     to,
+		IndividualAppointments: map[IndividualAppointmentCancelCancellationReason]int{},
+		Attendees:              map[AttendeeCancelCancellationReason]int{},
+	}
+
+	perPage := DefaultSyncPageSize
+	appointments := c.IterateIndividualAppointments(ctx, &ListIndividualAppointmentsGetParams{
+		PerPage: &perPage,
+		Q: Filters(
+			ListIndividualAppointmentsGetFilters.CancelledAt.GreaterOrEqual(from),
+			ListIndividualAppointmentsGetFilters.CancelledAt.Less(to),
+		),
+	}, reqEditors...)
+	for appointments.Next() {
+		appointment := appointments.Item()
+		if appointment.CancelledAt == nil {
+			continue
+		}
+
+		if reason, ok := appointment.CancelReason(); ok {
+			report.IndividualAppointments[reason]++
+		} else {
+			report.Unspecified++
+		}
+	}
+	if err := appointments.Err(); err != nil {
+		return nil, err
+	}
+
+	attendees := c.IterateAttendees(ctx, &ListAttendeesGetParams{
+		PerPage: &perPage,
+		Q: Filters(
+			ListAttendeesGetFilters.CancelledAt.GreaterOrEqual(from),
+			ListAttendeesGetFilters.CancelledAt.Less(to),
+		),
+	}, reqEditors...)
+	for attendees.Next() {
+		attendee := attendees.Item()
+		if attendee.CancelledAt == nil {
+			continue
+		}
+
+		if reason, ok := attendee.CancelReason(); ok {
+			report.Attendees[reason]++
+		} else {
+			report.Unspecified++
+		}
+	}
+	if err := attendees.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,275 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// TestCancellationReasons maps every reason to its
+// description and back, for attendees and appointments
+func TestCancellationReasons(t *testing.T) {
+	tests := []struct {
+		reason      int
+		description string
+		valid       bool
+	}{
+		{10, "Feeling Better", true},
+		{20, "Condition Worse", true},
+		{30, "Sick", true},
+		{31, "COVID-19 related", true},
+		{40, "Away", true},
+		{50, "Other", true},
+		{60, "Work", true},
+		{0, "", false},
+		{99, "Bored", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.reason), func(t *testing.T) {
+			attendee := cliniko.AttendeeCancelCancellationReason(tt.reason)
+			appointment := cliniko.IndividualAppointmentCancelCancellationReason(tt.reason)
+
+			if attendee.Valid() != tt.valid || appointment.Valid() != tt.valid {
+				t.Errorf("Valid = %v and %v, want %v", attendee.Valid(), appointment.Valid(), tt.valid)
+			}
+
+			wantString := tt.description
+			if !tt.valid {
+				wantString = ""
+			}
+			if got := string(attendee.Description()); got != wantString {
+				t.Errorf("attendee Description = %q, want %q", got, wantString)
+			}
+			if got := string(appointment.Description()); got != wantString {
+				t.Errorf("appointment Description = %q, want %q", got, wantString)
+			}
+
+			gotAttendee, err := cliniko.ParseAttendeeCancellationReason(
+				cliniko.AttendeeCancellationReasonDescription(tt.description))
+			if tt.valid && (err != nil || gotAttendee != attendee) {
+				t.Errorf("ParseAttendeeCancellationReason = %d, %v, want %d", gotAttendee, err, tt.reason)
+			}
+			if !tt.valid && !errors.Is(err, cliniko.ErrInvalidCancellationReason) {
+				t.Errorf("ParseAttendeeCancellationReason = %v, want ErrInvalidCancellationReason", err)
+			}
+
+			gotAppointment, err := cliniko.ParseIndividualAppointmentCancellationReason(
+				cliniko.IndividualAppointmentCancellationReasonDescription(tt.description))
+			if tt.valid && (err != nil || gotAppointment != appointment) {
+				t.Errorf("ParseIndividualAppointmentCancellationReason = %d, %v, want %d", gotAppointment, err, tt.reason)
+			}
+			if !tt.valid && !errors.Is(err, cliniko.ErrInvalidCancellationReason) {
+				t.Errorf("ParseIndividualAppointmentCancellationReason = %v, want ErrInvalidCancellationReason", err)
+			}
+		})
+	}
+
+	if got, want := cliniko.AttendeeCancelCancellationReason(99).String(), "AttendeeCancelCancellationReason(99)"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+	if got, want := cliniko.IndividualAppointmentCancelCancellationReasonSick.String(), "Sick"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+// TestCancel cancels attendees and appointments on the fake
+// server, invalid reasons are rejected without a request
+func TestCancel(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		reason  int
+		wantErr error
+	}{
+		{"valid", 31, nil},
+		{"zero", 0, cliniko.ErrInvalidCancellationReason},
+		{"unknown", 99, cliniko.ErrInvalidCancellationReason},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointmentId, err := srv.Seed("individual_appointments", map[string]any{
+				"starts_at": "2024-01-01T09:00:00Z",
+			})
+			if err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+			attendeeId, err := srv.Seed("attendees", map[string]any{})
+			if err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+
+			err = client.CancelIndividualAppointment(ctx, cliniko.IndividualAppointmentId(appointmentId),
+				cliniko.IndividualAppointmentCancellation{
+					Reason: cliniko.IndividualAppointmentCancelCancellationReason(tt.reason),
+					Note:   "Called in",
+				})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelIndividualAppointment = %v, want %v", err, tt.wantErr)
+			}
+			err = client.CancelAttendee(ctx, cliniko.AttendeeId(attendeeId),
+				cliniko.AttendeeCancellation{Reason: cliniko.AttendeeCancelCancellationReason(tt.reason)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelAttendee = %v, want %v", err, tt.wantErr)
+			}
+
+			appointment, err := client.GetIndividualAppointment(ctx, cliniko.IndividualAppointmentId(appointmentId))
+			if err != nil {
+				t.Fatalf("GetIndividualAppointment: %v", err)
+			}
+			attendee, err := client.GetAttendee(ctx, cliniko.AttendeeId(attendeeId))
+			if err != nil {
+				t.Fatalf("GetAttendee: %v", err)
+			}
+
+			if tt.wantErr != nil {
+				if appointment.CancelledAt != nil || attendee.CancelledAt != nil {
+					t.Errorf("cancelled with an invalid reason")
+				}
+				return
+			}
+
+			if got, ok := appointment.CancelReason(); !ok || int(got) != tt.reason {
+				t.Errorf("appointment CancelReason = %d, %v, want %d", got, ok, tt.reason)
+			}
+			if got, ok := attendee.CancelReason(); !ok || int(got) != tt.reason {
+				t.Errorf("attendee CancelReason = %d, %v, want %d", got, ok, tt.reason)
+			}
+			record, _ := srv.Record("individual_appointments", appointmentId)
+			if record["cancellation_note"] != "Called in" {
+				t.Errorf("cancellation_note = %v", record["cancellation_note"])
+			}
+		})
+	}
+}
+
+// TestCancelReason reads the reason from either field
+func TestCancelReason(t *testing.T) {
+	reason := func(r int) *int { return &r }
+	description := func(d string) *cliniko.IndividualAppointmentCancellationReasonDescription {
+		value := cliniko.IndividualAppointmentCancellationReasonDescription(d)
+		return &value
+	}
+
+	tests := []struct {
+		name        string
+		appointment cliniko.IndividualAppointment
+		want        cliniko.IndividualAppointmentCancelCancellationReason
+		wantOk      bool
+	}{
+		{"none", cliniko.IndividualAppointment{}, 0, false},
+		{"reason", cliniko.IndividualAppointment{CancellationReason: reason(40)},
+			cliniko.IndividualAppointmentCancelCancellationReasonAway, true},
+		{"reason wins", cliniko.IndividualAppointment{CancellationReason: reason(40), CancellationReasonDescription: description("Sick")},
+			cliniko.IndividualAppointmentCancelCancellationReasonAway, true},
+		{"description", cliniko.IndividualAppointment{CancellationReasonDescription: description("Work")},
+			cliniko.IndividualAppointmentCancelCancellationReasonWork, true},
+		{"unknown reason", cliniko.IndividualAppointment{CancellationReason: reason(99)}, 99, false},
+		{"unknown description", cliniko.IndividualAppointment{CancellationReasonDescription: description("Bored")}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.appointment.CancelReason()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CancelReason = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+// TestCancellationsBetween aggregates the cancellations of a
+// period, those without a known reason count as unspecified
+func TestCancellationsBetween(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	appointments := []map[string]any{
+		{"cancelled_at": "2024-01-01T10:00:00Z", "cancellation_reason": 30},
+		{"cancelled_at": "2024-01-02T10:00:00Z", "cancellation_reason_description": "Sick"},
+		{"cancelled_at": "2024-01-03T10:00:00Z", "cancellation_reason": 60},
+		{"cancelled_at": "2024-01-04T10:00:00Z"},
+		{"cancelled_at": "2024-01-05T10:00:00Z", "cancellation_reason": 99},
+		// outside the period or not cancelled
+		{"cancelled_at": "2023-12-31T23:59:59Z", "cancellation_reason": 30},
+		{"cancelled_at": "2024-02-01T00:00:00Z", "cancellation_reason": 30},
+		{},
+	}
+	for _, appointment := range appointments {
+		appointment["starts_at"] = "2024-01-10T09:00:00Z"
+		if _, err := srv.Seed("individual_appointments", appointment); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+	attendees := []map[string]any{
+		{"cancelled_at": "2024-01-01T10:00:00Z", "cancellation_reason": 30},
+		{"cancelled_at": "2024-01-06T10:00:00Z", "cancellation_reason_description": "Away"},
+		{"cancelled_at": "2024-01-07T10:00:00Z", "cancellation_reason_description": "Bored"},
+		{"cancelled_at": "2024-03-01T10:00:00Z", "cancellation_reason": 40},
+		{},
+	}
+	for _, attendee := range attendees {
+		if _, err := srv.Seed("attendees", attendee); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err := client.CancellationsBetween(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("CancellationsBetween: %v", err)
+	}
+
+	wantAppointments := map[cliniko.IndividualAppointmentCancelCancellationReason]int{
+		cliniko.IndividualAppointmentCancelCancellationReasonSick: 2,
+		cliniko.IndividualAppointmentCancelCancellationReasonWork: 1,
+	}
+	if !reflect.DeepEqual(report.IndividualAppointments, wantAppointments) {
+		t.Errorf("IndividualAppointments = %v, want %v", report.IndividualAppointments, wantAppointments)
+	}
+	wantAttendees := map[cliniko.AttendeeCancelCancellationReason]int{
+		cliniko.AttendeeCancelCancellationReasonSick: 1,
+		cliniko.AttendeeCancelCancellationReasonAway: 1,
+	}
+	if !reflect.DeepEqual(report.Attendees, wantAttendees) {
+		t.Errorf("Attendees = %v, want %v", report.Attendees, wantAttendees)
+	}
+	if report.Unspecified != 3 {
+		t.Errorf("Unspecified = %d, want 3", report.Unspecified)
+	}
+	if report.Total() != 8 {
+		t.Errorf("Total = %d, want 8", report.Total())
+	}
+
+	wantDescriptions := map[string]int{
+		"Sick":                                3,
+		"Work":                                1,
+		"Away":                                1,
+		cliniko.UnspecifiedCancellationReason: 3,
+	}
+	if got := report.ByDescription(); !reflect.DeepEqual(got, wantDescriptions) {
+		t.Errorf("ByDescription = %v, want %v", got, wantDescriptions)
+	}
+}
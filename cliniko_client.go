@@ -4,7 +4,6 @@
 package cliniko
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/xml"
@@ -12,10 +11,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"strings"
-	"time"
 )
 
 type UploadFileToS3BucketResponse struct {
@@ -53,14 +50,20 @@ type ClinikoClientInterface interface {
 type ClinikoClient struct {
 	ClientWithResponsesInterface
 
-	Client      *Client
+	Client *Client
+	// MaxUploadSize is the size limit of attachments in bytes,
+	// DefaultMaxUploadSize is used if it is not set
+	MaxUploadSize int64
+
 	token       string
 	vendor      string
 	vendorEmail string
 }
 
 // NewClinikoClient creates a new Extended Client that wraps
-// a ClientWithResponses type for advanced / additional functions
+// a ClientWithResponses type for advanced / additional functions.
+// The shard is deduced from the suffix of the token, use
+// NewClinikoClientWithOptions to override it.
 func NewClinikoClient(
 	token string,
 	vendor string,
@@ -69,16 +72,49 @@ func NewClinikoClient(
 ) (
 	*ClinikoClient, error,
 ) {
-	var shard string
-	tokenParts := strings.Split(token, "-")
-	if len(tokenParts) == 1 {
-		shard = "au1"
-	} else {
-		shard = tokenParts[1]
+	opts := make([]ClinikoClientOption, 0, len(requestEditors))
+	for _, fn := range requestEditors {
+		opts = append(opts, fn)
+	}
+	return NewClinikoClientWithOptions(token, vendor, vendorEmail, opts...)
+}
+
+// NewClinikoClientWithOptions creates a ClinikoClient like
+// NewClinikoClient, the shard is deduced from the suffix of
+// the token unless WithShard or WithBaseURL is given.
+func NewClinikoClientWithOptions(
+	token string,
+	vendor string,
+	vendorEmail string,
+	opts ...ClinikoClientOption,
+) (
+	*ClinikoClient, error,
+) {
+	var config Client
+	for _, o := range opts {
+		if err := o.applyTo(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Client == nil {
+		config.Client = newHTTPClient()
+	}
+
+	server := config.Server
+	if server == "" {
+		shard, err := tokenShard(token)
+		if err != nil {
+			return nil, err
+		}
+		server = shardURL(shard)
+	} else if token == "" {
+		return nil, fmt.Errorf("%w: token is empty", ErrInvalidToken)
 	}
 
 	client, err := NewClient(
-		fmt.Sprintf("https://api.%s.cliniko.com/v1", shard),
+		server,
+		WithHTTPClient(config.Client),
 	)
 
 	if err != nil {
@@ -98,7 +134,7 @@ func NewClinikoClient(
 	}
 
 	client.RequestEditors = append(client.RequestEditors, ret.addClinikoHeaders)
-	client.RequestEditors = append(client.RequestEditors, requestEditors...)
+	client.RequestEditors = append(client.RequestEditors, config.RequestEditors...)
 	return ret, nil
 }
 
@@ -113,7 +149,8 @@ func (c *ClinikoClient) addClinikoHeaders(
 }
 
 // NewUploadFileToS3BucketPostRequest generates requests
-// for UploadFileToS3Bucket
+// for UploadFileToS3Bucket with the background context,
+// see NewUploadFileToS3BucketPostRequestWithContext
 func (c *ClinikoClient) NewUploadFileToS3BucketPostRequest(
 	presignedUrl *PresignedPostGetResponse,
 	filename string,
@@ -121,49 +158,40 @@ func (c *ClinikoClient) NewUploadFileToS3BucketPostRequest(
 ) (
 	*http.Request, error,
 ) {
-	formFields := map[string]string{
-		"acl":                   string(*presignedUrl.JSON200.Fields.Acl),
-		"key":                   *presignedUrl.JSON200.Fields.Key,
-		"policy":                *presignedUrl.JSON200.Fields.Policy,
-		"success_action_status": string(*presignedUrl.JSON200.Fields.SuccessActionStatus),
-		"x-amz-date":            time.Now().UTC().Format("20060102T150405Z"),
-		"x-amz-algorithm":       *presignedUrl.JSON200.Fields.XAmzAlgorithm,
-		"x-amz-credential":      *presignedUrl.JSON200.Fields.XAmzCredential,
-		"x-amz-signature":       *presignedUrl.JSON200.Fields.XAmzSignature,
-	}
-
-	var form bytes.Buffer
-	s3Form := multipart.NewWriter(&form)
-	for key, val := range formFields {
-		fw, err := s3Form.CreateFormField(key)
-		if err != nil {
-			return nil, err
-		}
-		fw.Write([]byte(val))
-	}
-
-	fw, err := s3Form.CreateFormFile("file", filename)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = io.Copy(fw, fileContent)
-	if err != nil {
-		return nil, err
-	}
-	s3Form.Close()
-
-	req, err := http.NewRequest("POST", *presignedUrl.JSON200.Url, &form)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", s3Form.FormDataContentType())
-	req.Header.Add("Accept", "application/xml")
+	return c.NewUploadFileToS3BucketPostRequestWithContext(
+		context.Background(),
+		presignedUrl,
+		filename,
+		fileContent,
+	)
+}
 
-	return req, nil
+// NewUploadFileToS3BucketPostRequestWithContext generates
+// requests for UploadFileToS3Bucket. The file content is
+// streamed when the request is sent and its progress is
+// reported to the UploadProgressFunc of ctx.
+func (c *ClinikoClient) NewUploadFileToS3BucketPostRequestWithContext(
+	ctx context.Context,
+	presignedUrl *PresignedPostGetResponse,
+	filename string,
+	fileContent io.Reader,
+) (
+	*http.Request, error,
+) {
+	return c.newS3UploadRequest(
+		ctx,
+		presignedUrl,
+		filename,
+		fileContent,
+	)
 }
 
+// UploadFileToS3Bucket uploads the file content to the presigned
+// url without buffering it. Its size must be known up front: the
+// size of readers with a Len method, e.g. *bytes.Reader, and of
+// seekable readers, e.g. *os.File, is. Other readers are rejected
+// with ErrUploadSizeUnknown unless wrapped with SizedReader. Files
+// larger than MaxUploadSize are rejected with ErrUploadTooLarge.
 func (c *ClinikoClient) UploadFileToS3Bucket(
 	ctx context.Context,
 	presignedUrl *PresignedPostGetResponse,
@@ -173,7 +201,8 @@ func (c *ClinikoClient) UploadFileToS3Bucket(
 	*http.Response, error,
 ) {
 	req, err :=
-		c.NewUploadFileToS3BucketPostRequest(
+		c.NewUploadFileToS3BucketPostRequestWithContext(
+			ctx,
 			presignedUrl,
 			filename,
 			fileContent,
@@ -182,7 +211,6 @@ func (c *ClinikoClient) UploadFileToS3Bucket(
 		return nil, err
 	}
 
-	req = req.WithContext(ctx)
 	return c.Client.Client.Do(req)
 }
 
@@ -228,7 +256,9 @@ func (c *ClinikoClient) ParseUploadFileToS3BucketResponse(
 // calls to 1. create a presigned Amazon S3 bucket URL
 // 2. upload the file contents with the given name to
 // the presigned url from 1.
-// and 3. informs the Cliniko API of the new attachment
+// and 3. informs the Cliniko API of the new attachment.
+// If 3. fails, the returned presigned and s3 responses can
+// be passed to CompleteAttachment to retry it.
 func (c *ClinikoClient) CreateAttachment(
 	ctx context.Context,
 	patientId string,
@@ -254,7 +284,12 @@ func (c *ClinikoClient) CreateAttachment(
 	}
 
 	if presignedUrl.JSON200 == nil {
-		return presignedUrl, nil, nil, errors.New("presigned url request was unsuccessful")
+		return presignedUrl, nil, nil,
+			unsuccessfulResponse(
+				"presigned url request was unsuccessful",
+				presignedUrl.HTTPResponse,
+				presignedUrl.Body,
+			)
 	}
 
 	rsp, err :=
@@ -295,6 +330,44 @@ func (c *ClinikoClient) CreateAttachment(
 		return presignedUrl, s3Response, nil, errors.New("s3 request was unsuccessful")
 	}
 
+	attachmentPostResponse, err :=
+		c.CompleteAttachment(
+			ctx,
+			patientId,
+			description,
+			presignedUrl,
+			s3Response,
+			reqEditors...)
+
+	if err != nil {
+		return presignedUrl, s3Response, attachmentPostResponse, err
+	}
+
+	return presignedUrl, s3Response, attachmentPostResponse, nil
+}
+
+// CompleteAttachment informs the Cliniko API of a file that was
+// uploaded to the presigned url, which is the last step of
+// CreateAttachment. Use it to resume an attachment whose upload
+// succeeded but whose creation in Cliniko failed.
+func (c *ClinikoClient) CompleteAttachment(
+	ctx context.Context,
+	patientId string,
+	description *string,
+	presignedUrl *PresignedPostGetResponse,
+	s3Response *UploadFileToS3BucketResponse,
+	reqEditors ...RequestEditorFn,
+) (
+	*CreateUploadedPatientAttachmentPostResponse, error,
+) {
+	if presignedUrl == nil || presignedUrl.JSON200 == nil {
+		return nil, errors.New("presigned url response has no content")
+	}
+
+	if s3Response == nil || s3Response.XML201 == nil {
+		return nil, errors.New("s3 response has no uploaded key")
+	}
+
 	uploadUrl := fmt.Sprintf("%s/%s",
 		*presignedUrl.JSON200.Url,
 		s3Response.XML201.Key)
@@ -312,12 +385,17 @@ func (c *ClinikoClient) CreateAttachment(
 			reqEditors...)
 
 	if err != nil {
-		return presignedUrl, s3Response, nil, err
+		return nil, err
 	}
 
 	if attachmentPostResponse.JSON201 == nil {
-		return presignedUrl, s3Response, attachmentPostResponse, errors.New("post attachment to cliniko request was unsuccessful")
+		return attachmentPostResponse,
+			unsuccessfulResponse(
+				"post attachment to cliniko request was unsuccessful",
+				attachmentPostResponse.HTTPResponse,
+				attachmentPostResponse.Body,
+			)
 	}
 
-	return presignedUrl, s3Response, attachmentPostResponse, nil
+	return attachmentPostResponse, nil
 }
@@ -0,0 +1,335 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxDownloadRedirects is the number of redirects
+// followed when downloading attachment contents
+const maxDownloadRedirects = 10
+
+// ErrAttachmentSize is returned when the downloaded contents
+// of an attachment do not match the size in its metadata
+var ErrAttachmentSize = errors.New("cliniko: attachment size mismatch")
+
+// CheckRedirect is the redirect policy of the http.Client values
+// created by this package. It does not forward the Authorization
+// header, which S3 rejects on presigned urls. Set it on custom
+// clients passed to WithHTTPDoer when downloading attachments.
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxDownloadRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxDownloadRedirects)
+	}
+
+	req.Header.Del("Authorization")
+	return nil
+}
+
+// newHTTPClient returns the default Doer
+func newHTTPClient() *http.Client {
+	return &http.Client{CheckRedirect: CheckRedirect}
+}
+
+// AttachmentMeta describes the file of a patient attachment,
+// either an uploaded file or a full patient export
+type AttachmentMeta struct {
+	Id          string
+	Filename    string
+	ContentType string
+	// Size is the file size in bytes or -1 if it is unknown
+	Size int64
+	// ContentLink is the API link that redirects to the file
+	ContentLink string
+}
+
+// AttachmentMeta returns the file metadata of the
+// patient attachment with the given id
+func (c *ClinikoClient) AttachmentMeta(
+	ctx context.Context,
+	id string,
+	reqEditors ...RequestEditorFn,
+) (
+	AttachmentMeta, error,
+) {
+	rsp, err := c.GetPatientAttachmentGetWithResponse(ctx, id, nil, reqEditors...)
+	if err != nil {
+		return AttachmentMeta{}, err
+	}
+
+	if rsp.JSON200 == nil {
+		return AttachmentMeta{},
+			unsuccessfulResponse(
+				"get patient attachment request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+
+	// both kinds of attachment share the file fields
+	var attachment UploadedPatientAttachment
+	if err := json.Unmarshal(rsp.Body, &attachment); err != nil {
+		return AttachmentMeta{}, err
+	}
+
+	if attachment.Content == nil ||
+		attachment.Content.Links == nil ||
+		attachment.Content.Links.Self == nil {
+		return AttachmentMeta{}, fmt.Errorf("cliniko: patient attachment %s has no content link", id)
+	}
+
+	meta := AttachmentMeta{
+		Id:          id,
+		Size:        -1,
+		ContentLink: *attachment.Content.Links.Self,
+	}
+	if attachment.Filename != nil {
+		meta.Filename = *attachment.Filename
+	}
+	if attachment.ContentType != nil {
+		meta.ContentType = *attachment.ContentType
+	}
+	if attachment.Size != nil {
+		meta.Size = int64(*attachment.Size)
+	}
+	return meta, nil
+}
+
+// DownloadAttachment writes the contents of the patient
+// attachment with the given id to w and returns the
+// number of bytes written
+func (c *ClinikoClient) DownloadAttachment(
+	ctx context.Context,
+	id string,
+	w io.Writer,
+	reqEditors ...RequestEditorFn,
+) (
+	int64, error,
+) {
+	contents, _, err := c.OpenAttachment(ctx, id, reqEditors...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = contents.Close() }()
+
+	return io.Copy(w, contents)
+}
+
+// OpenAttachment opens the contents of the patient attachment
+// with the given id. Reading returns ErrAttachmentSize if the
+// contents end before the size given in the metadata.
+func (c *ClinikoClient) OpenAttachment(
+	ctx context.Context,
+	id string,
+	reqEditors ...RequestEditorFn,
+) (
+	io.ReadCloser, AttachmentMeta, error,
+) {
+	return c.OpenAttachmentRange(ctx, id, 0, 0, reqEditors...)
+}
+
+// OpenAttachmentRange opens length bytes of the contents of the
+// patient attachment starting at offset, a length of 0 reads to
+// the end. Use it to resume a download:
+//
+//	contents, _, err := client.OpenAttachmentRange(ctx, id, written, 0)
+func (c *ClinikoClient) OpenAttachmentRange(
+	ctx context.Context,
+	id string,
+	offset int64,
+	length int64,
+	reqEditors ...RequestEditorFn,
+) (
+	io.ReadCloser, AttachmentMeta, error,
+) {
+	if offset < 0 || length < 0 {
+		return nil, AttachmentMeta{}, fmt.Errorf("cliniko: invalid range %d+%d", offset, length)
+	}
+
+	meta, err := c.AttachmentMeta(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	expected := int64(-1)
+	if meta.Size >= 0 {
+		if offset > meta.Size {
+			return nil, meta, fmt.Errorf(
+				"cliniko: offset %d is beyond the attachment size %d",
+				offset,
+				meta.Size,
+			)
+		}
+		expected = meta.Size - offset
+	}
+	if length > 0 && (expected < 0 || length < expected) {
+		expected = length
+	}
+	if expected == 0 {
+		return io.NopCloser(strings.NewReader("")), meta, nil
+	}
+
+	rsp, err := c.getContents(ctx, meta.ContentLink, offset, length, reqEditors)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	switch rsp.StatusCode {
+	case http.StatusPartialContent:
+		var start int64
+		_, err := fmt.Sscanf(rsp.Header.Get("Content-Range"), "bytes %d-", &start)
+		if err != nil || start != offset {
+			_ = rsp.Body.Close()
+			return nil, meta, fmt.Errorf(
+				"cliniko: unexpected content range %q",
+				rsp.Header.Get("Content-Range"),
+			)
+		}
+	case http.StatusOK:
+		// the range was ignored, skip to the offset
+		if _, err := io.CopyN(io.Discard, rsp.Body, offset); err != nil {
+			_ = rsp.Body.Close()
+			return nil, meta, fmt.Errorf("%w: %v", ErrAttachmentSize, err)
+		}
+		if rsp.ContentLength >= 0 {
+			rsp.ContentLength -= offset
+		}
+	default:
+		body, err := io.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+		if err != nil {
+			return nil, meta, err
+		}
+		return nil, meta,
+			unsuccessfulResponse(
+				"download attachment request was unsuccessful",
+				rsp,
+				body,
+			)
+	}
+
+	if expected >= 0 && rsp.ContentLength >= 0 && rsp.ContentLength < expected {
+		_ = rsp.Body.Close()
+		return nil, meta, fmt.Errorf(
+			"%w: %d bytes available, expected %d",
+			ErrAttachmentSize,
+			rsp.ContentLength,
+			expected,
+		)
+	}
+
+	return &attachmentReader{body: rsp.Body, remaining: expected}, meta, nil
+}
+
+// getContents requests the content link with authentication
+// and follows the redirect to the file without it
+func (c *ClinikoClient) getContents(
+	ctx context.Context,
+	link string,
+	offset int64,
+	length int64,
+	reqEditors []RequestEditorFn,
+) (
+	*http.Response, error,
+) {
+	byteRange := ""
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	} else if offset > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	setRange := func(ctx context.Context, req *http.Request) error {
+		if byteRange != "" {
+			req.Header.Set("Range", byteRange)
+		}
+		return nil
+	}
+
+	rsp, err := c.getLink(ctx, link, append(reqEditors, setRange)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Doers that do not follow redirects themselves
+	for redirects := 0; isRedirect(rsp.StatusCode); redirects++ {
+		location, err := rsp.Location()
+		_ = rsp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if redirects == maxDownloadRedirects {
+			return nil, fmt.Errorf("cliniko: stopped after %d redirects", maxDownloadRedirects)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		_ = setRange(ctx, req)
+
+		rsp, err = c.Client.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rsp, nil
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently,
+		http.StatusFound,
+		http.StatusSeeOther,
+		http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// attachmentReader reads at most remaining bytes
+// and reports contents that end early
+type attachmentReader struct {
+	body      io.ReadCloser
+	remaining int64
+	read      int64
+}
+
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+	if r.remaining > 0 && int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+	if r.remaining > 0 {
+		r.remaining -= int64(n)
+	}
+
+	if err == io.EOF && r.remaining > 0 {
+		return n, fmt.Errorf(
+			"%w: contents ended after %d bytes, %d bytes missing",
+			ErrAttachmentSize,
+			r.read,
+			r.remaining,
+		)
+	}
+	return n, err
+}
+
+func (r *attachmentReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,187 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// sentRequest is a request seen by recordingTransport
+type sentRequest struct {
+	path          string
+	authorization bool
+	byteRange     string
+}
+
+// recordingTransport records every request, including the
+// redirects followed by an http.Client, and optionally drops
+// the Range header like servers that do not support it
+type recordingTransport struct {
+	ignoreRange bool
+
+	mu       sync.Mutex
+	requests []sentRequest
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, sentRequest{
+		path:          req.URL.Path,
+		authorization: req.Header.Get("Authorization") != "",
+		byteRange:     req.Header.Get("Range"),
+	})
+	t.mu.Unlock()
+
+	if t.ignoreRange && req.Header.Get("Range") != "" {
+		req = req.Clone(req.Context())
+		req.Header.Del("Range")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestOpenAttachmentRange downloads parts of an attachment, the
+// Range header reaches the file and Authorization does not
+func TestOpenAttachmentRange(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+
+	tests := []struct {
+		name        string
+		offset      int64
+		length      int64
+		ignoreRange bool
+		want        string
+		wantRange   string
+	}{
+		{"whole file", 0, 0, false, string(content), ""},
+		{"from offset", 5, 0, false, string(content[5:]), "bytes=5-"},
+		{"offset and length", 5, 4, false, "5678", "bytes=5-8"},
+		{"length", 0, 3, false, "012", "bytes=0-2"},
+		{"range ignored", 5, 4, true, "5678", "bytes=5-8"},
+	}
+
+	redirects := []struct {
+		name   string
+		follow bool
+	}{
+		{"client follows redirects", true},
+		{"client returns redirects", false},
+	}
+
+	for _, redirect := range redirects {
+		for _, tt := range tests {
+			t.Run(redirect.name+"/"+tt.name, func(t *testing.T) {
+				transport := &recordingTransport{ignoreRange: tt.ignoreRange}
+				httpClient := &http.Client{Transport: transport, CheckRedirect: cliniko.CheckRedirect}
+				if !redirect.follow {
+					httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+						return http.ErrUseLastResponse
+					}
+				}
+
+				srv := clinikotest.NewServer()
+				defer srv.Close()
+				client, err := srv.NewClinikoClient("clinikotest", "test@example.com", cliniko.WithHTTPDoer(httpClient))
+				if err != nil {
+					t.Fatalf("NewClinikoClient: %v", err)
+				}
+				patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+				if err != nil {
+					t.Fatalf("Seed: %v", err)
+				}
+				id, err := srv.SeedAttachment(patientId, "notes.txt", content, "")
+				if err != nil {
+					t.Fatalf("SeedAttachment: %v", err)
+				}
+
+				contents, meta, err := client.OpenAttachmentRange(context.Background(), id, tt.offset, tt.length)
+				if err != nil {
+					t.Fatalf("OpenAttachmentRange: %v", err)
+				}
+				got, err := io.ReadAll(contents)
+				_ = contents.Close()
+				if err != nil {
+					t.Fatalf("read: %v", err)
+				}
+
+				if string(got) != tt.want {
+					t.Errorf("contents = %q, want %q", got, tt.want)
+				}
+				if meta.Filename != "notes.txt" || meta.Size != int64(len(content)) {
+					t.Errorf("meta = %+v", meta)
+				}
+
+				var contentRequests []sentRequest
+				for _, req := range transport.requests {
+					if strings.HasSuffix(req.path, "/contents") || !strings.Contains(req.path, "patient_attachments") {
+						contentRequests = append(contentRequests, req)
+					}
+				}
+				if len(contentRequests) != 2 {
+					t.Fatalf("content requests = %+v, want the link and the file", contentRequests)
+				}
+				link, file := contentRequests[0], contentRequests[1]
+				if !link.authorization {
+					t.Errorf("content link requested without Authorization")
+				}
+				if file.authorization {
+					t.Errorf("Authorization sent to the file after the redirect")
+				}
+				if link.byteRange != tt.wantRange || file.byteRange != tt.wantRange {
+					t.Errorf("Range = %q and %q, want %q", link.byteRange, file.byteRange, tt.wantRange)
+				}
+			})
+		}
+	}
+}
+
+// TestCheckRedirect downloads with a client that forwards the
+// Authorization header, which the file storage rejects
+func TestCheckRedirect(t *testing.T) {
+	tests := []struct {
+		name          string
+		checkRedirect func(*http.Request, []*http.Request) error
+		wantErr       bool
+	}{
+		{"CheckRedirect", cliniko.CheckRedirect, false},
+		{"default policy", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &http.Client{CheckRedirect: tt.checkRedirect}
+			srv := clinikotest.NewServer()
+			defer srv.Close()
+			client, err := srv.NewClinikoClient("clinikotest", "test@example.com", cliniko.WithHTTPDoer(httpClient))
+			if err != nil {
+				t.Fatalf("NewClinikoClient: %v", err)
+			}
+			patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+			if err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+			id, err := srv.SeedAttachment(patientId, "notes.txt", []byte("notes"), "")
+			if err != nil {
+				t.Fatalf("SeedAttachment: %v", err)
+			}
+
+			var b bytes.Buffer
+			_, err = client.DownloadAttachment(context.Background(), id, &b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadAttachment = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && b.String() != "notes" {
+				t.Errorf("contents = %q, want %q", b.String(), "notes")
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnauthorized is returned for 401 responses
+	ErrUnauthorized = errors.New("cliniko: unauthorized")
+	// ErrNotFound is returned for 404 responses
+	ErrNotFound = errors.New("cliniko: not found")
+	// ErrRateLimited is returned for 429 responses
+	ErrRateLimited = errors.New("cliniko: rate limited")
+	// ErrServer is returned for 5xx responses
+	ErrServer = errors.New("cliniko: server error")
+)
+
+// APIError describes a non successful response of the API.
+// Use errors.Is with ErrUnauthorized, ErrNotFound,
+// ErrRateLimited or ErrServer to check for a category and
+// errors.As to access the response details.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	// RetryAfter is the delay requested by the API
+	// before sending the next request, only set for 429
+	RetryAfter time.Duration
+
+	kind error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf(
+		"cliniko: unexpected status code %d, response body: %s",
+		e.StatusCode,
+		e.Body,
+	)
+}
+
+// Unwrap returns the sentinel error matching the status code
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// ValidationErrorResponse is returned for 422 responses
+// and carries the per field errors of the ValidationError
+type ValidationErrorResponse struct {
+	ValidationError
+
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface
+func (e *ValidationErrorResponse) Error() string {
+	message := "validation failed"
+	if e.Message != nil {
+		message = *e.Message
+	}
+
+	fields := e.FieldErrors()
+	if len(fields) == 0 {
+		return "cliniko: " + message
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	details := make([]string, 0, len(names))
+	for _, name := range names {
+		details = append(details, fmt.Sprintf("%s %s", name, fields[name]))
+	}
+	return fmt.Sprintf("cliniko: %s: %s", message, strings.Join(details, ", "))
+}
+
+// FieldErrors returns the error message of each invalid field
+func (e *ValidationErrorResponse) FieldErrors() map[string]string {
+	if e.Errors == nil {
+		return map[string]string{}
+	}
+	return *e.Errors
+}
+
+// ResponseError returns nil for 2xx responses and a typed
+// error for all other responses. The body must be the
+// already read response body.
+func ResponseError(rsp *http.Response, body []byte) error {
+	if rsp == nil {
+		return errors.New("cliniko: no response")
+	}
+
+	switch {
+	case rsp.StatusCode >= 200 && rsp.StatusCode < 300:
+		return nil
+	case rsp.StatusCode == http.StatusUnprocessableEntity:
+		validationErr := &ValidationErrorResponse{
+			StatusCode: rsp.StatusCode,
+			Body:       body,
+		}
+		_ = json.Unmarshal(body, &validationErr.ValidationError)
+		return validationErr
+	}
+
+	apiErr := &APIError{
+		StatusCode: rsp.StatusCode,
+		Body:       body,
+	}
+
+	switch {
+	case rsp.StatusCode == http.StatusUnauthorized:
+		apiErr.kind = ErrUnauthorized
+	case rsp.StatusCode == http.StatusNotFound:
+		apiErr.kind = ErrNotFound
+	case rsp.StatusCode == http.StatusTooManyRequests:
+		apiErr.kind = ErrRateLimited
+		apiErr.RetryAfter = parseRetryAfter(rsp.Header.Get("Retry-After"), time.Now())
+	case rsp.StatusCode >= 500:
+		apiErr.kind = ErrServer
+	}
+	return apiErr
+}
+
+// unsuccessfulResponse returns an error with the given message
+// that wraps the typed error of the response, if any
+func unsuccessfulResponse(message string, rsp *http.Response, body []byte) error {
+	if err := ResponseError(rsp, body); err != nil {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return errors.New(message)
+}
+
+// Check turns a non successful response of any *WithResponse
+// function into a typed error:
+//
+//	patient, err := Check(client.GetPatientGetWithResponse(ctx, id, nil))
+//	if errors.Is(err, ErrNotFound) {
+//		...
+//	}
+//
+// On success the response is returned unchanged.
+func Check[R any](rsp R, err error) (R, error) {
+	if err != nil {
+		return rsp, err
+	}
+
+	httpResponse, body, ok := responseFields(rsp)
+	if !ok {
+		return rsp, fmt.Errorf("cliniko: %T is not a response type", rsp)
+	}
+	return rsp, ResponseError(httpResponse, body)
+}
+
+// responseFields extracts the HTTPResponse and Body fields
+// shared by all generated *Response types
+func responseFields(rsp any) (*http.Response, []byte, bool) {
+	value := reflect.ValueOf(rsp)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil, nil, false
+	}
+
+	value = value.Elem()
+	if value.Kind() != reflect.Struct {
+		return nil, nil, false
+	}
+
+	httpField := value.FieldByName("HTTPResponse")
+	bodyField := value.FieldByName("Body")
+	if !httpField.IsValid() || !bodyField.IsValid() {
+		return nil, nil, false
+	}
+
+	httpResponse, ok := httpField.Interface().(*http.Response)
+	if !ok {
+		return nil, nil, false
+	}
+
+	body, _ := bodyField.Interface().([]byte)
+	return httpResponse, body, true
+}
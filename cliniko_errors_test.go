@@ -0,0 +1,208 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		header         http.Header
+		body           string
+		want           error
+		wantRetryAfter time.Duration
+	}{
+		{"ok", http.StatusOK, nil, `{}`, nil, 0},
+		{"no content", http.StatusNoContent, nil, ``, nil, 0},
+		{"unauthorized", http.StatusUnauthorized, nil, `{"message":"Unauthorized"}`, ErrUnauthorized, 0},
+		{"not found", http.StatusNotFound, nil, `{"message":"Not Found"}`, ErrNotFound, 0},
+		{"rate limited", http.StatusTooManyRequests, http.Header{"Retry-After": {"30"}}, ``, ErrRateLimited, 30 * time.Second},
+		{"internal server error", http.StatusInternalServerError, nil, ``, ErrServer, 0},
+		{"service unavailable", http.StatusServiceUnavailable, nil, ``, ErrServer, 0},
+		{"bad request", http.StatusBadRequest, nil, `{"message":"Bad Request"}`, nil, 0},
+	}
+
+	sentinels := []error{ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrServer}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp := &http.Response{StatusCode: tt.status, Header: tt.header}
+			err := ResponseError(rsp, []byte(tt.body))
+
+			if tt.status < 300 {
+				if err != nil {
+					t.Fatalf("ResponseError = %v, want nil", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("ResponseError = %T, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || string(apiErr.Body) != tt.body {
+				t.Errorf("APIError = %d %q, want %d %q", apiErr.StatusCode, apiErr.Body, tt.status, tt.body)
+			}
+			if apiErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", apiErr.RetryAfter, tt.wantRetryAfter)
+			}
+			for _, sentinel := range sentinels {
+				if errors.Is(err, sentinel) != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, sentinel, !(sentinel == tt.want))
+				}
+			}
+		})
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		rsp := &http.Response{StatusCode: http.StatusUnprocessableEntity}
+		err := ResponseError(rsp, []byte(`{"message":"Invalid","errors":{"last_name":"can't be blank","email":"is invalid"}}`))
+
+		var validationErr *ValidationErrorResponse
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("ResponseError = %T, want *ValidationErrorResponse", err)
+		}
+		if got := validationErr.FieldErrors()["last_name"]; got != "can't be blank" {
+			t.Errorf("last_name = %q", got)
+		}
+		if want := "cliniko: Invalid: email is invalid, last_name can't be blank"; err.Error() != want {
+			t.Errorf("Error = %q, want %q", err, want)
+		}
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel) {
+				t.Errorf("validation error is %v", sentinel)
+			}
+		}
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		if err := ResponseError(nil, nil); err == nil {
+			t.Error("ResponseError(nil) = nil")
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	notFound := &http.Response{StatusCode: http.StatusNotFound}
+	ok := &http.Response{StatusCode: http.StatusOK}
+	sendErr := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		check   func() error
+		want    error
+		wantErr bool
+	}{
+		{"json response", func() error {
+			_, err := Check(&GetPatientGetResponse{HTTPResponse: ok, Body: []byte(`{}`)}, nil)
+			return err
+		}, nil, false},
+		{"json response not found", func() error {
+			_, err := Check(&GetPatientGetResponse{HTTPResponse: notFound}, nil)
+			return err
+		}, ErrNotFound, true},
+		{"list response not found", func() error {
+			_, err := Check(&ListPatientsGetResponse{HTTPResponse: notFound}, nil)
+			return err
+		}, ErrNotFound, true},
+		{"no content response not found", func() error {
+			_, err := Check(&ArchivePatientPostResponse{HTTPResponse: notFound}, nil)
+			return err
+		}, ErrNotFound, true},
+		{"send error", func() error {
+			_, err := Check[*GetPatientGetResponse](nil, sendErr)
+			return err
+		}, sendErr, true},
+		{"nil response", func() error {
+			_, err := Check[*GetPatientGetResponse](nil, nil)
+			return err
+		}, nil, true},
+		{"no http response", func() error {
+			_, err := Check(&GetPatientGetResponse{}, nil)
+			return err
+		}, nil, true},
+		{"not a pointer", func() error {
+			_, err := Check(GetPatientGetResponse{HTTPResponse: ok}, nil)
+			return err
+		}, nil, true},
+		{"not a response", func() error {
+			_, err := Check(&Patient{}, nil)
+			return err
+		}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Check = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckResponseTypes makes sure every generated response
+// type has the fields Check reads
+func TestCheckResponseTypes(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "cliniko.go", nil, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !strings.HasSuffix(typeSpec.Name.Name, "Response") {
+				continue
+			}
+
+			count++
+			fields := map[string]string{}
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields[name.Name] = typeSource(field.Type)
+				}
+			}
+			if fields["Body"] != "[]byte" || fields["HTTPResponse"] != "*http.Response" {
+				t.Errorf("%s has Body %q and HTTPResponse %q", typeSpec.Name.Name, fields["Body"], fields["HTTPResponse"])
+			}
+		}
+	}
+	if count == 0 {
+		t.Fatal("no response types in cliniko.go")
+	}
+}
+
+// typeSource returns the source of a simple type expression
+func typeSource(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return "*" + typeSource(expr.X)
+	case *ast.ArrayType:
+		return "[]" + typeSource(expr.Elt)
+	case *ast.SelectorExpr:
+		return typeSource(expr.X) + "." + expr.Sel.Name
+	}
+	return ""
+}
@@ -0,0 +1,346 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ExportManifestName is the name of the manifest
+// written last into every patient export
+const ExportManifestName = "manifest.json"
+
+// ExportSink receives the files of a patient export. Names are
+// slash separated paths relative to the root of the export.
+type ExportSink interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// ExportManifest describes the files of a patient export
+type ExportManifest struct {
+	PatientId  string       `json:"patient_id"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Files      []ExportFile `json:"files"`
+}
+
+// ExportFile is a file of a patient export with its checksum
+type ExportFile struct {
+	Path string `json:"path"`
+	// Resource is the list endpoint the records were read from,
+	// empty for attachment contents
+	Resource string `json:"resource,omitempty"`
+	Records  int    `json:"records,omitempty"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// exportSection is a per patient list endpoint, records of list
+// endpoints without a patient path are filtered by patient_id
+type exportSection struct {
+	operation string
+	key       string
+	first     func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error)
+}
+
+var exportPerPage = DefaultSyncPageSize
+
+var exportSections = []exportSection{
+	{"ListPatientCasesGet", "patient_cases",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListPatientCasesGet(ctx, &ListPatientCasesGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListIndividualAppointmentsGet", "individual_appointments",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListIndividualAppointmentsGet(ctx, &ListIndividualAppointmentsGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListAttendeesGet", "attendees",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListAttendeesGet(ctx, &ListAttendeesGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListInvoicesForPatientGet", "invoices",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListInvoicesForPatientGet(ctx, patientId, &ListInvoicesForPatientGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListTreatmentNotesForPatientGet", "treatment_notes",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListTreatmentNotesForPatientGet(ctx, patientId, &ListTreatmentNotesForPatientGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListMedicalAlertsForPatientGet", "medical_alerts",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListMedicalAlertsForPatientGet(ctx, patientId, &ListMedicalAlertsForPatientGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListPatientFormsGet", "patient_forms",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListPatientFormsGet(ctx, &ListPatientFormsGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListCommunicationsGet", "communications",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListCommunicationsGet(ctx, &ListCommunicationsGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+	{"ListPatientAttachmentsForPatientGet", "patient_attachments",
+		func(ctx context.Context, c *ClinikoClient, patientId string, q *[]string, reqEditors []RequestEditorFn) (*http.Response, error) {
+			return c.Client.ListPatientAttachmentsForPatientGet(ctx, patientId, &ListPatientAttachmentsForPatientGetParams{PerPage: &exportPerPage, Q: q}, reqEditors...)
+		}},
+}
+
+// filter returns the q[] parameter that selects all records
+// of the patient including archived and deleted ones
+func (s exportSection) filter(patientId string) *[]string {
+	fields := filterFields[s.operation]
+	q := []string{}
+	if !strings.Contains(s.operation, "ForPatient") {
+		q = append(q, "patient_id:="+patientId)
+	}
+	for _, field := range []string{"archived_at", "deleted_at"} {
+		if _, ok := fields[field]; ok {
+			q = append(q, field+":*")
+		}
+	}
+	return &q
+}
+
+// ExportPatient writes everything stored for a patient to sink:
+// the patient itself, its cases, appointments, attendees,
+// invoices, treatment notes, medical alerts, forms,
+// communications and attachments, each as a JSON file, the
+// contents of every attachment below attachments/ and finally
+// a manifest with the SHA-256 checksum of each file.
+//
+//	f, _ := os.Create("patient-1.zip")
+//	sink := NewZipExportSink(f)
+//	manifest, err := client.ExportPatient(ctx, "1", sink)
+//	...
+//	err = sink.Close()
+func (c *ClinikoClient) ExportPatient(
+	ctx context.Context,
+	patientId string,
+	sink ExportSink,
+	reqEditors ...RequestEditorFn,
+) (
+	*ExportManifest, error,
+) {
+	manifest := &ExportManifest{
+		PatientId:  patientId,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	// offboarded patients are usually archived
+	patient, err := c.GetPatientGetWithResponse(ctx, patientId, &GetPatientGetParams{
+		Q: Filters(GetPatientGetFilters.ArchivedAt.Any()),
+	}, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if patient.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient request was unsuccessful",
+				patient.HTTPResponse,
+				patient.Body,
+			)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, patient.Body, "", "  "); err != nil {
+		return nil, err
+	}
+	file, err := writeExportFile(sink, "patient.json", &indented)
+	if err != nil {
+		return nil, err
+	}
+	file.Resource, file.Records = "GetPatientGet", 1
+	manifest.Files = append(manifest.Files, file)
+
+	var attachments []json.RawMessage
+	for _, section := range exportSections {
+		records, err := Paginate[json.RawMessage](ctx, c, section.key,
+			func(ctx context.Context) (*http.Response, error) {
+				return section.first(ctx, c, patientId, section.filter(patientId), reqEditors)
+			},
+			reqEditors...).All()
+		if err != nil {
+			return nil, fmt.Errorf("cliniko: export %s: %w", section.key, err)
+		}
+		if records == nil {
+			records = []json.RawMessage{}
+		}
+
+		body, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		file, err := writeExportFile(sink, section.key+".json", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		file.Resource, file.Records = section.operation, len(records)
+		manifest.Files = append(manifest.Files, file)
+
+		if section.key == "patient_attachments" {
+			attachments = records
+		}
+	}
+
+	for _, record := range attachments {
+		file, err := c.exportAttachment(ctx, sink, record, reqEditors)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	body, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writeExportFile(sink, ExportManifestName, bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// exportAttachment writes the contents of an attachment
+// to attachments/<id>/<filename>
+func (c *ClinikoClient) exportAttachment(
+	ctx context.Context,
+	sink ExportSink,
+	record json.RawMessage,
+	reqEditors []RequestEditorFn,
+) (
+	ExportFile, error,
+) {
+	var attachment struct {
+		Id       *string `json:"id"`
+		Filename *string `json:"filename"`
+	}
+	if err := json.Unmarshal(record, &attachment); err != nil {
+		return ExportFile{}, err
+	}
+	if attachment.Id == nil {
+		return ExportFile{}, errors.New("cliniko: patient attachment without id")
+	}
+
+	filename := "contents"
+	if attachment.Filename != nil {
+		filename = exportFilename(*attachment.Filename)
+	}
+	name := path.Join("attachments", *attachment.Id, filename)
+
+	contents, _, err := c.OpenAttachment(ctx, *attachment.Id, reqEditors...)
+	if err != nil {
+		return ExportFile{}, fmt.Errorf("cliniko: export attachment %s: %w", *attachment.Id, err)
+	}
+	defer func() { _ = contents.Close() }()
+
+	return writeExportFile(sink, name, contents)
+}
+
+// exportFilename strips directories from a filename
+// so that it can not escape its attachment directory
+func exportFilename(filename string) string {
+	filename = strings.ReplaceAll(filename, "\\", "/")
+	filename = path.Base(filename)
+	if filename == "." || filename == ".." || filename == "/" {
+		return "contents"
+	}
+	return filename
+}
+
+// writeExportFile copies r into a new file of the
+// sink and returns its size and checksum
+func writeExportFile(sink ExportSink, name string, r io.Reader) (ExportFile, error) {
+	w, err := sink.Create(name)
+	if err != nil {
+		return ExportFile{}, err
+	}
+
+	checksum := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, checksum), r)
+	if err != nil {
+		_ = w.Close()
+		return ExportFile{}, fmt.Errorf("cliniko: export %s: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return ExportFile{}, err
+	}
+
+	return ExportFile{
+		Path:   name,
+		Size:   size,
+		SHA256: hex.EncodeToString(checksum.Sum(nil)),
+	}, nil
+}
+
+// ZipExportSink writes a patient export into a zip archive
+type ZipExportSink struct {
+	zip *zip.Writer
+}
+
+// NewZipExportSink creates a ZipExportSink writing to w,
+// Close must be called once the export is complete
+func NewZipExportSink(w io.Writer) *ZipExportSink {
+	return &ZipExportSink{zip: zip.NewWriter(w)}
+}
+
+// Create adds a file to the archive, the previous
+// file must have been written completely
+func (s *ZipExportSink) Create(name string) (io.WriteCloser, error) {
+	w, err := s.zip.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nopWriteCloser{w}, nil
+}
+
+// Close writes the central directory of the archive,
+// it does not close the underlying writer
+func (s *ZipExportSink) Close() error {
+	return s.zip.Close()
+}
+
+// DirExportSink writes a patient export into a directory
+type DirExportSink struct {
+	dir string
+}
+
+// NewDirExportSink creates a DirExportSink writing below dir,
+// which is created if it does not exist
+func NewDirExportSink(dir string) *DirExportSink {
+	return &DirExportSink{dir: dir}
+}
+
+// Create creates the file and its parent directories
+func (s *DirExportSink) Create(name string) (io.WriteCloser, error) {
+	target := filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+name)))
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
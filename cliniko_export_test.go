@@ -0,0 +1,63 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+func TestExportArchivedPatient(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	ctx := context.Background()
+
+	patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if _, err := srv.Seed("individual_appointments", map[string]any{
+		"patient_id": patientId,
+		"starts_at":  "2024-01-01T09:00:00Z",
+	}); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if _, err := cliniko.Check(client.ArchivePatientPostWithResponse(ctx, patientId)); err != nil {
+		t.Fatalf("ArchivePatientPost: %v", err)
+	}
+
+	requests := 0
+	countRequests := func(ctx context.Context, req *http.Request) error {
+		requests++
+		return nil
+	}
+
+	manifest, err := client.ExportPatient(ctx, patientId, cliniko.NewDirExportSink(t.TempDir()), countRequests)
+	if err != nil {
+		t.Fatalf("ExportPatient: %v", err)
+	}
+
+	records, listed := map[string]int{}, 0
+	for _, file := range manifest.Files {
+		if file.Resource != "" {
+			records[file.Resource] += file.Records
+			listed++
+		}
+	}
+	if records["GetPatientGet"] != 1 || records["ListIndividualAppointmentsGet"] != 1 {
+		t.Errorf("exported records = %v, want the patient and its appointment", records)
+	}
+	if requests < listed {
+		t.Errorf("request editors applied to %d requests, want one per section", requests)
+	}
+}
@@ -0,0 +1,399 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+//go:generate go run gen_filter_fields.go
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterKind is the format of a field that can be
+// used in the q[] parameter of a list endpoint
+type FilterKind string
+
+const (
+	FilterKindNumeric  FilterKind = "numeric"
+	FilterKindDecimal  FilterKind = "decimal"
+	FilterKindDate     FilterKind = "date"
+	FilterKindDateTime FilterKind = "date-time"
+	FilterKindString   FilterKind = "string"
+	FilterKindBoolean  FilterKind = "boolean"
+	FilterKindArray    FilterKind = "array"
+)
+
+// FilterOperator is an operator of a q[] filter
+type FilterOperator string
+
+const (
+	FilterEqual          FilterOperator = "="
+	FilterNotEqual       FilterOperator = "!="
+	FilterGreater        FilterOperator = ">"
+	FilterGreaterOrEqual FilterOperator = ">="
+	FilterLess           FilterOperator = "<"
+	FilterLessOrEqual    FilterOperator = "<="
+	FilterContains       FilterOperator = "~"
+	FilterLike           FilterOperator = "~~"
+	FilterAny            FilterOperator = "*"
+)
+
+// filterOperators lists the operators allowed for each kind
+var filterOperators = map[FilterKind][]FilterOperator{
+	FilterKindNumeric:  {FilterEqual, FilterNotEqual, FilterGreater, FilterGreaterOrEqual, FilterLess, FilterLessOrEqual},
+	FilterKindDecimal:  {FilterEqual, FilterNotEqual, FilterGreater, FilterGreaterOrEqual, FilterLess, FilterLessOrEqual},
+	FilterKindDate:     {FilterEqual, FilterNotEqual, FilterGreater, FilterGreaterOrEqual, FilterLess, FilterLessOrEqual},
+	FilterKindDateTime: {FilterEqual, FilterNotEqual, FilterGreater, FilterGreaterOrEqual, FilterLess, FilterLessOrEqual, FilterAny},
+	FilterKindString:   {FilterEqual, FilterNotEqual, FilterContains, FilterLike},
+	FilterKindBoolean:  {FilterEqual},
+	FilterKindArray:    {FilterContains},
+}
+
+// Filter is a single entry of the q[] parameter of
+// the list endpoint whose params type is P
+type Filter[P any] struct {
+	field    string
+	operator FilterOperator
+	value    string
+}
+
+// String returns the filter as sent in q[], e.g. "id:>=5"
+func (f Filter[P]) String() string {
+	return f.field + ":" + string(f.operator) + f.value
+}
+
+// Filters returns the q[] parameter for the given filters.
+// All filters must belong to the same endpoint:
+//
+//	params := &ListPatientsGetParams{
+//		Q: Filters(
+//			ListPatientsGetFilters.UpdatedAt.GreaterOrEqual(since),
+//			ListPatientsGetFilters.LastName.Like("Sm%"),
+//		),
+//	}
+//
+// Without filters it returns nil, as an empty q[] is sent
+// as a single empty entry.
+func Filters[P any](filters ...Filter[P]) *[]string {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	q := make([]string, 0, len(filters))
+	for _, f := range filters {
+		q = append(q, f.String())
+	}
+	return &q
+}
+
+// NumericFilter filters an integer field such as id
+type NumericFilter[P any] struct {
+	Field string
+}
+
+func (f NumericFilter[P]) filter(operator FilterOperator, value int64) Filter[P] {
+	return Filter[P]{f.Field, operator, strconv.FormatInt(value, 10)}
+}
+
+// Equal matches records where the field equals value
+func (f NumericFilter[P]) Equal(value int64) Filter[P] {
+	return f.filter(FilterEqual, value)
+}
+
+// NotEqual matches records where the field does not equal value
+func (f NumericFilter[P]) NotEqual(value int64) Filter[P] {
+	return f.filter(FilterNotEqual, value)
+}
+
+// Greater matches records where the field is greater than value
+func (f NumericFilter[P]) Greater(value int64) Filter[P] {
+	return f.filter(FilterGreater, value)
+}
+
+// GreaterOrEqual matches records where the field is at least value
+func (f NumericFilter[P]) GreaterOrEqual(value int64) Filter[P] {
+	return f.filter(FilterGreaterOrEqual, value)
+}
+
+// Less matches records where the field is less than value
+func (f NumericFilter[P]) Less(value int64) Filter[P] {
+	return f.filter(FilterLess, value)
+}
+
+// LessOrEqual matches records where the field is at most value
+func (f NumericFilter[P]) LessOrEqual(value int64) Filter[P] {
+	return f.filter(FilterLessOrEqual, value)
+}
+
+// DecimalFilter filters a decimal field such as price
+type DecimalFilter[P any] struct {
+	Field string
+}
+
+func (f DecimalFilter[P]) filter(operator FilterOperator, value float64) Filter[P] {
+	return Filter[P]{f.Field, operator, strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// Equal matches records where the field equals value
+func (f DecimalFilter[P]) Equal(value float64) Filter[P] {
+	return f.filter(FilterEqual, value)
+}
+
+// NotEqual matches records where the field does not equal value
+func (f DecimalFilter[P]) NotEqual(value float64) Filter[P] {
+	return f.filter(FilterNotEqual, value)
+}
+
+// Greater matches records where the field is greater than value
+func (f DecimalFilter[P]) Greater(value float64) Filter[P] {
+	return f.filter(FilterGreater, value)
+}
+
+// GreaterOrEqual matches records where the field is at least value
+func (f DecimalFilter[P]) GreaterOrEqual(value float64) Filter[P] {
+	return f.filter(FilterGreaterOrEqual, value)
+}
+
+// Less matches records where the field is less than value
+func (f DecimalFilter[P]) Less(value float64) Filter[P] {
+	return f.filter(FilterLess, value)
+}
+
+// LessOrEqual matches records where the field is at most value
+func (f DecimalFilter[P]) LessOrEqual(value float64) Filter[P] {
+	return f.filter(FilterLessOrEqual, value)
+}
+
+// DateFilter filters a date field such as date_of_birth,
+// only the year, month and day of the given time are used
+type DateFilter[P any] struct {
+	Field string
+}
+
+func (f DateFilter[P]) filter(operator FilterOperator, value time.Time) Filter[P] {
+	return Filter[P]{f.Field, operator, value.Format("2006-01-02")}
+}
+
+// Equal matches records where the field is on the given date
+func (f DateFilter[P]) Equal(value time.Time) Filter[P] {
+	return f.filter(FilterEqual, value)
+}
+
+// NotEqual matches records where the field is not on the given date
+func (f DateFilter[P]) NotEqual(value time.Time) Filter[P] {
+	return f.filter(FilterNotEqual, value)
+}
+
+// Greater matches records where the field is after the given date
+func (f DateFilter[P]) Greater(value time.Time) Filter[P] {
+	return f.filter(FilterGreater, value)
+}
+
+// GreaterOrEqual matches records where the field is on or after the given date
+func (f DateFilter[P]) GreaterOrEqual(value time.Time) Filter[P] {
+	return f.filter(FilterGreaterOrEqual, value)
+}
+
+// Less matches records where the field is before the given date
+func (f DateFilter[P]) Less(value time.Time) Filter[P] {
+	return f.filter(FilterLess, value)
+}
+
+// LessOrEqual matches records where the field is on or before the given date
+func (f DateFilter[P]) LessOrEqual(value time.Time) Filter[P] {
+	return f.filter(FilterLessOrEqual, value)
+}
+
+// DateTimeFilter filters a date-time field such as updated_at
+type DateTimeFilter[P any] struct {
+	Field string
+}
+
+func (f DateTimeFilter[P]) filter(operator FilterOperator, value time.Time) Filter[P] {
+	return Filter[P]{f.Field, operator, value.UTC().Format(time.RFC3339)}
+}
+
+// Equal matches records where the field equals the given time
+func (f DateTimeFilter[P]) Equal(value time.Time) Filter[P] {
+	return f.filter(FilterEqual, value)
+}
+
+// NotEqual matches records where the field does not equal the given time
+func (f DateTimeFilter[P]) NotEqual(value time.Time) Filter[P] {
+	return f.filter(FilterNotEqual, value)
+}
+
+// Greater matches records where the field is after the given time
+func (f DateTimeFilter[P]) Greater(value time.Time) Filter[P] {
+	return f.filter(FilterGreater, value)
+}
+
+// GreaterOrEqual matches records where the field is at or after the given time
+func (f DateTimeFilter[P]) GreaterOrEqual(value time.Time) Filter[P] {
+	return f.filter(FilterGreaterOrEqual, value)
+}
+
+// Less matches records where the field is before the given time
+func (f DateTimeFilter[P]) Less(value time.Time) Filter[P] {
+	return f.filter(FilterLess, value)
+}
+
+// LessOrEqual matches records where the field is at or before the given time
+func (f DateTimeFilter[P]) LessOrEqual(value time.Time) Filter[P] {
+	return f.filter(FilterLessOrEqual, value)
+}
+
+// Any matches records regardless of the value of the field,
+// e.g. archived_at:* includes archived records in the result
+func (f DateTimeFilter[P]) Any() Filter[P] {
+	return Filter[P]{f.Field, FilterAny, ""}
+}
+
+// StringFilter filters a string field such as email
+type StringFilter[P any] struct {
+	Field string
+}
+
+// Equal matches records where the field equals value
+func (f StringFilter[P]) Equal(value string) Filter[P] {
+	return Filter[P]{f.Field, FilterEqual, value}
+}
+
+// NotEqual matches records where the field does not equal value
+func (f StringFilter[P]) NotEqual(value string) Filter[P] {
+	return Filter[P]{f.Field, FilterNotEqual, value}
+}
+
+// Contains matches records where the field contains value
+func (f StringFilter[P]) Contains(value string) Filter[P] {
+	return Filter[P]{f.Field, FilterContains, value}
+}
+
+// Like matches records where the field matches the
+// pattern, in which % matches any sequence of characters
+func (f StringFilter[P]) Like(pattern string) Filter[P] {
+	return Filter[P]{f.Field, FilterLike, pattern}
+}
+
+// BooleanFilter filters a boolean field
+type BooleanFilter[P any] struct {
+	Field string
+}
+
+// Equal matches records where the field equals value
+func (f BooleanFilter[P]) Equal(value bool) Filter[P] {
+	return Filter[P]{f.Field, FilterEqual, strconv.FormatBool(value)}
+}
+
+// ArrayFilter filters an array field such as patient_ids
+type ArrayFilter[P any] struct {
+	Field string
+}
+
+// Contains matches records where the array contains value
+func (f ArrayFilter[P]) Contains(value string) Filter[P] {
+	return Filter[P]{f.Field, FilterContains, value}
+}
+
+// FilterFields returns the fields that can be filtered on for
+// the given operation, e.g. "ListPatientsGet", by their kind
+func FilterFields(operation string) (map[string]FilterKind, bool) {
+	fields, ok := filterFields[operation]
+	if !ok {
+		return nil, false
+	}
+
+	copied := make(map[string]FilterKind, len(fields))
+	for field, kind := range fields {
+		copied[field] = kind
+	}
+	return copied, true
+}
+
+// ValidateFilters checks q[] entries built by hand against the
+// fields and operators known for the given operation
+func ValidateFilters(operation string, q []string) error {
+	fields, ok := filterFields[operation]
+	if !ok {
+		return fmt.Errorf("cliniko: %s does not support filters", operation)
+	}
+
+	for _, entry := range q {
+		field, rest, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("cliniko: filter %q has no operator", entry)
+		}
+
+		kind, ok := fields[field]
+		if !ok {
+			return fmt.Errorf(
+				"cliniko: unknown filter field %q for %s, available: %s",
+				field,
+				operation,
+				strings.Join(sortedKeys(fields), ", "),
+			)
+		}
+
+		operator, value, ok := cutOperator(rest, filterOperators[kind])
+		if !ok {
+			return fmt.Errorf("cliniko: invalid operator in filter %q for %s field", entry, kind)
+		}
+
+		if err := validateFilterValue(kind, operator, value); err != nil {
+			return fmt.Errorf("cliniko: invalid value in filter %q: %w", entry, err)
+		}
+	}
+	return nil
+}
+
+// cutOperator splits the longest allowed operator off the
+// start of a filter, so that >= is not mistaken for >
+func cutOperator(rest string, allowed []FilterOperator) (FilterOperator, string, bool) {
+	var found FilterOperator
+	for _, operator := range allowed {
+		if strings.HasPrefix(rest, string(operator)) && len(operator) > len(found) {
+			found = operator
+		}
+	}
+	if found == "" {
+		return "", "", false
+	}
+	return found, strings.TrimPrefix(rest, string(found)), true
+}
+
+func validateFilterValue(kind FilterKind, operator FilterOperator, value string) error {
+	if operator == FilterAny {
+		if value != "" {
+			return fmt.Errorf("%s takes no value", operator)
+		}
+		return nil
+	}
+
+	var err error
+	switch kind {
+	case FilterKindNumeric:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case FilterKindDecimal:
+		_, err = strconv.ParseFloat(value, 64)
+	case FilterKindDate:
+		_, err = time.Parse("2006-01-02", value)
+	case FilterKindDateTime:
+		_, err = time.Parse(time.RFC3339, value)
+	case FilterKindBoolean:
+		_, err = strconv.ParseBool(value)
+	}
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+)
+
+// TestFilters encodes filters of every kind into the q[]
+// parameter of a request, the entries pass ValidateFilters
+func TestFilters(t *testing.T) {
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("AEDT", 11*60*60))
+
+	tests := []struct {
+		name    string
+		filter  cliniko.Filter[cliniko.ListPatientsGetParams]
+		want    string
+		wantErr bool
+	}{
+		{"numeric", cliniko.ListPatientsGetFilters.Id.GreaterOrEqual(5), "id:>=5", false},
+		{"numeric not equal", cliniko.ListPatientsGetFilters.Id.NotEqual(-1), "id:!=-1", false},
+		{"date-time in utc", cliniko.ListPatientsGetFilters.UpdatedAt.Greater(since), "updated_at:>2024-01-01T16:04:05Z", false},
+		{"date-time any", cliniko.ListPatientsGetFilters.ArchivedAt.Any(), "archived_at:*", false},
+		{"date", cliniko.ListPatientsGetFilters.DateOfBirth.LessOrEqual(since), "date_of_birth:<=2024-01-02", false},
+		{"string like", cliniko.ListPatientsGetFilters.LastName.Like("Sm%"), "last_name:~~Sm%", false},
+		{"string contains", cliniko.ListPatientsGetFilters.Email.Contains("@example.com"), "email:~@example.com", false},
+		{"string with colon", cliniko.ListPatientsGetFilters.FirstName.Equal("a:b"), "first_name:=a:b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &cliniko.ListPatientsGetParams{Q: cliniko.Filters(tt.filter)}
+			req, err := cliniko.NewListPatientsGetRequest("https://api.example.com/v1", params)
+			if err != nil {
+				t.Fatalf("NewListPatientsGetRequest: %v", err)
+			}
+
+			got := req.URL.Query()["q[]"]
+			if !reflect.DeepEqual(got, []string{tt.want}) {
+				t.Errorf("q[] = %q, want %q", got, tt.want)
+			}
+			if err := cliniko.ValidateFilters("ListPatientsGet", got); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilters(%q) = %v", got, err)
+			}
+		})
+	}
+
+	t.Run("other kinds", func(t *testing.T) {
+		q := cliniko.Filters(
+			cliniko.ListAppointmentTypesGetFilters.ShowInOnlineBookings.Equal(true),
+			cliniko.ListAppointmentTypesGetFilters.AppointmentReminderTemplateIds.Contains("7"),
+		)
+		want := []string{"show_in_online_bookings:=true", "appointment_reminder_template_ids:~7"}
+		if !reflect.DeepEqual(*q, want) {
+			t.Errorf("Filters = %q, want %q", *q, want)
+		}
+
+		price := cliniko.Filters(cliniko.ListConcessionPricesGetFilters.Price.Less(12.5))
+		if want := []string{"price:<12.5"}; !reflect.DeepEqual(*price, want) {
+			t.Errorf("Filters = %q, want %q", *price, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		params := &cliniko.ListPatientsGetParams{Q: cliniko.Filters[cliniko.ListPatientsGetParams]()}
+		req, err := cliniko.NewListPatientsGetRequest("https://api.example.com/v1", params)
+		if err != nil {
+			t.Fatalf("NewListPatientsGetRequest: %v", err)
+		}
+		if q, ok := req.URL.Query()["q[]"]; ok {
+			t.Errorf("q[] = %q, want none", q)
+		}
+	})
+}
+
+// TestValidateFilters checks hand written q[] entries
+func TestValidateFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		q         []string
+		wantErr   bool
+	}{
+		{"valid", "ListPatientsGet", []string{"id:>=5", "last_name:~~Sm%", "archived_at:*"}, false},
+		{"unknown operation", "ListNothingGet", []string{"id:=1"}, true},
+		{"unknown field", "ListPatientsGet", []string{"nickname:=Bob"}, true},
+		{"no operator", "ListPatientsGet", []string{"id"}, true},
+		{"operator of other kind", "ListPatientsGet", []string{"id:~5"}, true},
+		{"invalid number", "ListPatientsGet", []string{"id:=five"}, true},
+		{"invalid date-time", "ListPatientsGet", []string{"updated_at:>=2024-01-01"}, true},
+		{"any with value", "ListPatientsGet", []string{"archived_at:*2024-01-01T00:00:00Z"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cliniko.ValidateFilters(tt.operation, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilters(%q, %q) = %v, want error %v", tt.operation, tt.q, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrClassFull is returned when a group appointment
+	// has no free place for another attendee
+	ErrClassFull = errors.New("cliniko: group appointment is full")
+	// ErrAlreadyEnrolled is returned when a patient already
+	// attends or waits for a group appointment
+	ErrAlreadyEnrolled = errors.New("cliniko: patient is already enrolled")
+)
+
+// WaitlistEntry is a patient waiting for a place
+// in a group appointment
+type WaitlistEntry struct {
+	PatientId     PatientId
+	PatientCaseId *PatientCaseId
+	AddedAt       time.Time
+}
+
+// GroupOccupancy is the occupancy of a group appointment
+type GroupOccupancy struct {
+	Appointment GroupAppointment
+	// Attendees is the number of active attendees
+	Attendees int
+	// Capacity is MaxAttendees, -1 if it is not limited
+	Capacity int
+	// Waitlisted is the number of patients on the waitlist
+	Waitlisted int
+}
+
+// Free returns the number of free places, -1 if unlimited
+func (o GroupOccupancy) Free() int {
+	if o.Capacity < 0 {
+		return -1
+	}
+	if free := o.Capacity - o.Attendees; free > 0 {
+		return free
+	}
+	return 0
+}
+
+// GroupClasses manages the attendees of group appointments.
+// Cliniko has no waitlists, so they are kept in memory by
+// GroupClasses and patients are enrolled from them in order
+// whenever an attendee is cancelled. It is safe for
+// concurrent use.
+type GroupClasses struct {
+	c *ClinikoClient
+
+	mu        sync.Mutex
+	waitlists map[GroupAppointmentId][]WaitlistEntry
+}
+
+// NewGroupClasses creates a GroupClasses with empty waitlists
+func (c *ClinikoClient) NewGroupClasses() *GroupClasses {
+	return &GroupClasses{
+		c:         c,
+		waitlists: map[GroupAppointmentId][]WaitlistEntry{},
+	}
+}
+
+// activeAttendee reports whether an attendee holds a place
+func activeAttendee(attendee Attendee) bool {
+	return attendee.CancelledAt == nil &&
+		attendee.ArchivedAt == nil &&
+		attendee.DeletedAt == nil
+}
+
+// Attendees returns the attendees of a group appointment
+// that are neither cancelled, archived nor deleted
+func (g *GroupClasses) Attendees(
+	ctx context.Context,
+	id GroupAppointmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	[]Attendee, error,
+) {
+	perPage := DefaultSyncPageSize
+	it := g.c.IterateAttendeesForGroupAppointment(
+		ctx,
+		string(id),
+		&ListAttendeesForGroupAppointmentGetParams{PerPage: &perPage},
+		reqEditors...,
+	)
+
+	var attendees []Attendee
+	for it.Next() {
+		if attendee := it.Item(); activeAttendee(attendee) {
+			attendees = append(attendees, attendee)
+		}
+	}
+	return attendees, it.Err()
+}
+
+// Occupancy returns the occupancy of a single group appointment
+func (g *GroupClasses) Occupancy(
+	ctx context.Context,
+	id GroupAppointmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	*GroupOccupancy, error,
+) {
+	appointment, err := g.c.GetGroupAppointment(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy, _, err := g.occupancy(ctx, *appointment, reqEditors)
+	return occupancy, err
+}
+
+// occupancy returns the occupancy and active attendees of an appointment
+func (g *GroupClasses) occupancy(
+	ctx context.Context,
+	appointment GroupAppointment,
+	reqEditors []RequestEditorFn,
+) (
+	*GroupOccupancy, []Attendee, error,
+) {
+	if appointment.Id == nil {
+		return nil, nil, errors.New("cliniko: group appointment has no id")
+	}
+	id := GroupAppointmentId(*appointment.Id)
+
+	attendees, err := g.Attendees(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	capacity := -1
+	if appointment.MaxAttendees != nil {
+		capacity = *appointment.MaxAttendees
+	}
+
+	g.mu.Lock()
+	waitlisted := len(g.waitlists[id])
+	g.mu.Unlock()
+
+	return &GroupOccupancy{
+		Appointment: appointment,
+		Attendees:   len(attendees),
+		Capacity:    capacity,
+		Waitlisted:  waitlisted,
+	}, attendees, nil
+}
+
+// OccupancyBetween returns the occupancy of all group
+// appointments starting within [from, to), ordered by start
+func (g *GroupClasses) OccupancyBetween(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	reqEditors ...RequestEditorFn,
+) (
+	[]GroupOccupancy, error,
+) {
+	perPage := DefaultSyncPageSize
+	appointments, err := g.c.IterateGroupAppointments(ctx, &ListGroupAppointmentsGetParams{
+		PerPage: &perPage,
+		Q: Filters(
+			ListGroupAppointmentsGetFilters.StartsAt.GreaterOrEqual(from),
+			ListGroupAppointmentsGetFilters.StartsAt.Less(to),
+		),
+	}, reqEditors...).All()
+	if err != nil {
+		return nil, err
+	}
+
+	occupancies := make([]GroupOccupancy, 0, len(appointments))
+	for _, appointment := range appointments {
+		if appointment.ArchivedAt != nil || appointment.DeletedAt != nil {
+			continue
+		}
+
+		occupancy, _, err := g.occupancy(ctx, appointment, reqEditors)
+		if err != nil {
+			return nil, err
+		}
+		occupancies = append(occupancies, *occupancy)
+	}
+
+	sort.SliceStable(occupancies, func(i, j int) bool {
+		a, b := occupancies[i].Appointment.StartsAt, occupancies[j].Appointment.StartsAt
+		return a != nil && (b == nil || a.Before(*b))
+	})
+	return occupancies, nil
+}
+
+// Enroll adds a patient as attendee to a group appointment. It
+// returns ErrClassFull if all places are taken and
+// ErrAlreadyEnrolled if the patient already attends. Once the
+// attendee is created the attendees are counted again and, if
+// other enrollments took the remaining places in the meantime,
+// the new attendee is archived and the error is ErrClassFull,
+// together with the error of the archive request if that failed.
+// Places go to the attendees created first, so of two requests
+// racing for the last place only the later one rolls back.
+func (g *GroupClasses) Enroll(
+	ctx context.Context,
+	id GroupAppointmentId,
+	patientId PatientId,
+	patientCaseId *PatientCaseId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	appointment, err := g.c.GetGroupAppointment(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy, attendees, err := g.occupancy(ctx, *appointment, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attendee := range attendees {
+		if attending, ok := attendee.PatientId(); ok && attending == patientId {
+			return nil, fmt.Errorf("%w: patient %s in group appointment %s", ErrAlreadyEnrolled, patientId, id)
+		}
+	}
+
+	if occupancy.Free() == 0 {
+		return nil, fmt.Errorf("%w: %d of %d places taken", ErrClassFull, occupancy.Attendees, occupancy.Capacity)
+	}
+
+	created, err := g.createAttendee(ctx, id, patientId, patientCaseId, reqEditors)
+	if err != nil || occupancy.Capacity < 0 || created.Id == nil {
+		return created, err
+	}
+
+	attendees, err = g.Attendees(ctx, id, reqEditors...)
+	if err != nil {
+		return created, err
+	}
+
+	place := enrollmentPlace(attendees, created)
+	if place < occupancy.Capacity {
+		return created, nil
+	}
+
+	full := fmt.Errorf("%w: %d of %d places taken", ErrClassFull, place, occupancy.Capacity)
+	if _, err := Check(g.c.ArchiveAttendeePostWithResponse(ctx, *created.Id, reqEditors...)); err != nil {
+		return created, errors.Join(full, fmt.Errorf("archive attendee %s: %w", *created.Id, err))
+	}
+	return nil, full
+}
+
+// enrollmentPlace returns the number of attendees that were
+// created before the given one, attendees created in the same
+// second are ordered by id
+func enrollmentPlace(attendees []Attendee, attendee *Attendee) int {
+	place := 0
+	for _, other := range attendees {
+		if other.Id == nil || *other.Id == *attendee.Id {
+			continue
+		}
+
+		switch {
+		case other.CreatedAt == nil || attendee.CreatedAt == nil:
+			if compareIds(*other.Id, *attendee.Id) < 0 {
+				place++
+			}
+		case other.CreatedAt.Before(*attendee.CreatedAt),
+			other.CreatedAt.Equal(*attendee.CreatedAt) && compareIds(*other.Id, *attendee.Id) < 0:
+			place++
+		}
+	}
+	return place
+}
+
+// EnrollOrWaitlist enrolls a patient or, if the group appointment
+// is full, puts the patient on its waitlist. It returns the
+// attendee or nil and true if the patient has been waitlisted.
+func (g *GroupClasses) EnrollOrWaitlist(
+	ctx context.Context,
+	id GroupAppointmentId,
+	patientId PatientId,
+	patientCaseId *PatientCaseId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, bool, error,
+) {
+	attendee, err := g.Enroll(ctx, id, patientId, patientCaseId, reqEditors...)
+	if !errors.Is(err, ErrClassFull) {
+		return attendee, false, err
+	}
+
+	if err := g.AddToWaitlist(id, WaitlistEntry{
+		PatientId:     patientId,
+		PatientCaseId: patientCaseId,
+	}); err != nil {
+		return nil, false, err
+	}
+	return nil, true, nil
+}
+
+// Waitlist returns the patients waiting for a place in
+// a group appointment, in the order they are enrolled
+func (g *GroupClasses) Waitlist(id GroupAppointmentId) []WaitlistEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]WaitlistEntry(nil), g.waitlists[id]...)
+}
+
+// AddToWaitlist appends a patient to the waitlist of a group
+// appointment, AddedAt defaults to the current time
+func (g *GroupClasses) AddToWaitlist(id GroupAppointmentId, entry WaitlistEntry) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, waiting := range g.waitlists[id] {
+		if waiting.PatientId == entry.PatientId {
+			return fmt.Errorf("%w: patient %s on the waitlist of %s", ErrAlreadyEnrolled, entry.PatientId, id)
+		}
+	}
+
+	if entry.AddedAt.IsZero() {
+		entry.AddedAt = time.Now()
+	}
+	g.waitlists[id] = append(g.waitlists[id], entry)
+	return nil
+}
+
+// RemoveFromWaitlist removes a patient from the waitlist of
+// a group appointment and reports whether it was waiting
+func (g *GroupClasses) RemoveFromWaitlist(id GroupAppointmentId, patientId PatientId) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	waitlist := g.waitlists[id]
+	for i, waiting := range waitlist {
+		if waiting.PatientId == patientId {
+			g.waitlists[id] = append(waitlist[:i:i], waitlist[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Cancel cancels an attendee with the given reason and enrolls
+// the first patient of the waitlist into the freed place. It
+// returns the attendee enrolled from the waitlist, if any.
+func (g *GroupClasses) Cancel(
+	ctx context.Context,
+	attendeeId AttendeeId,
+	reason AttendeeCancelCancellationReason,
+	note string,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	attendee, err := g.c.GetAttendee(ctx, attendeeId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.c.CancelAttendee(ctx, attendeeId, AttendeeCancellation{
+		Reason: reason,
+		Note:   note,
+	}, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingId, ok := attendee.BookingId()
+	if !ok {
+		return nil, nil
+	}
+	return g.promote(ctx, GroupAppointmentId(bookingId), reqEditors)
+}
+
+// promote enrolls patients from the waitlist while there are free
+// places, patients that can not be enrolled are kept waiting
+func (g *GroupClasses) promote(
+	ctx context.Context,
+	id GroupAppointmentId,
+	reqEditors []RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	for _, entry := range g.Waitlist(id) {
+		attendee, err := g.Enroll(ctx, id, entry.PatientId, entry.PatientCaseId, reqEditors...)
+		switch {
+		case errors.Is(err, ErrClassFull):
+			return nil, nil
+		case errors.Is(err, ErrAlreadyEnrolled):
+			g.RemoveFromWaitlist(id, entry.PatientId)
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		g.RemoveFromWaitlist(id, entry.PatientId)
+		return attendee, nil
+	}
+	return nil, nil
+}
+
+// MoveResult is the outcome of Move
+type MoveResult struct {
+	// Moved maps the ids of the moved attendees to the
+	// attendees created in the target. If the attendee could
+	// neither be archived in the source nor removed from the
+	// target again, it is included as it attends both.
+	Moved map[AttendeeId]Attendee
+	// Skipped lists attendees that were not moved because
+	// the target was full or the patient already attends it
+	Skipped []AttendeeId
+}
+
+// Move moves the active attendees of a group appointment, or only
+// the given ones, to another group appointment. Each attendee is
+// created in the target and then archived in the source, as long
+// as the target has free places. If the archive request fails the
+// attendee created in the target is archived again and Move stops
+// with the error.
+func (g *GroupClasses) Move(
+	ctx context.Context,
+	from GroupAppointmentId,
+	to GroupAppointmentId,
+	attendeeIds []AttendeeId,
+	reqEditors ...RequestEditorFn,
+) (
+	*MoveResult, error,
+) {
+	attendees, err := g.Attendees(ctx, from, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[AttendeeId]bool, len(attendeeIds))
+	for _, id := range attendeeIds {
+		selected[id] = true
+	}
+
+	result := &MoveResult{Moved: map[AttendeeId]Attendee{}}
+	for _, attendee := range attendees {
+		if attendee.Id == nil {
+			continue
+		}
+		id := AttendeeId(*attendee.Id)
+		if len(selected) > 0 && !selected[id] {
+			continue
+		}
+
+		patientId, ok := attendee.PatientId()
+		if !ok {
+			result.Skipped = append(result.Skipped, id)
+			continue
+		}
+
+		var patientCaseId *PatientCaseId
+		if caseId, ok := attendee.PatientCaseId(); ok {
+			patientCaseId = &caseId
+		}
+
+		moved, err := g.Enroll(ctx, to, patientId, patientCaseId, reqEditors...)
+		if errors.Is(err, ErrClassFull) || errors.Is(err, ErrAlreadyEnrolled) {
+			result.Skipped = append(result.Skipped, id)
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if _, err := Check(g.c.ArchiveAttendeePostWithResponse(ctx, string(id), reqEditors...)); err != nil {
+			err = fmt.Errorf("archive attendee %s after moving it: %w", id, err)
+			if moved.Id == nil {
+				result.Moved[id] = *moved
+				return result, err
+			}
+			if _, undoErr := Check(g.c.ArchiveAttendeePostWithResponse(ctx, *moved.Id, reqEditors...)); undoErr != nil {
+				result.Moved[id] = *moved
+				return result, errors.Join(err, fmt.Errorf("archive moved attendee %s: %w", *moved.Id, undoErr))
+			}
+			return result, err
+		}
+		result.Moved[id] = *moved
+	}
+	return result, nil
+}
+
+// createAttendee adds a patient without checking the capacity
+func (g *GroupClasses) createAttendee(
+	ctx context.Context,
+	id GroupAppointmentId,
+	patientId PatientId,
+	patientCaseId *PatientCaseId,
+	reqEditors []RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	body := CreateAttendeePostJSONRequestBody{
+		BookingId: stringPointer(string(id)),
+		PatientId: stringPointer(string(patientId)),
+	}
+	if patientCaseId != nil {
+		body.PatientCaseId = stringPointer(string(*patientCaseId))
+	}
+
+	rsp, err := g.c.CreateAttendeePostWithResponse(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON201 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"create attendee request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON201, nil
+}
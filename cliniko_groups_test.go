@@ -0,0 +1,321 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// TestEnrollRace enrolls a patient into the last place while
+// another patient is enrolled concurrently, only the later
+// attendee is rolled back
+func TestEnrollRace(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		otherCreated time.Time
+		wantErr      error
+		// wantActive includes the other attendee, whose own
+		// Enroll would roll it back if it was created later
+		wantActive int
+	}{
+		{"other enrolled first", now.Add(-time.Second), cliniko.ErrClassFull, 1},
+		{"other enrolled later", now.Add(time.Second), nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := clinikotest.NewServer(clinikotest.WithClock(func() time.Time { return now }))
+			defer srv.Close()
+			client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+			if err != nil {
+				t.Fatalf("NewClinikoClient: %v", err)
+			}
+
+			classId, err := srv.Seed("group_appointments", map[string]any{
+				"starts_at":     now.Add(time.Hour).Format(time.RFC3339),
+				"max_attendees": 1,
+			})
+			if err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+			patientIds := seedPatients(t, srv, "Jane", "John")
+
+			// the other attendee is stored right before
+			// the one Enroll creates
+			enrollOther := func(ctx context.Context, req *http.Request) error {
+				if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/attendees") {
+					return nil
+				}
+				_, err := srv.Seed("attendees", map[string]any{
+					"booking_id": classId,
+					"patient_id": patientIds[1],
+					"created_at": tt.otherCreated.Format(time.RFC3339),
+				})
+				return err
+			}
+
+			classes := client.NewGroupClasses()
+			attendee, err := classes.Enroll(context.Background(),
+				cliniko.GroupAppointmentId(classId), cliniko.PatientId(patientIds[0]), nil, enrollOther)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if enrolled := attendee != nil; enrolled != (tt.wantErr == nil) {
+				t.Errorf("enrolled = %v, want %v", enrolled, tt.wantErr == nil)
+			}
+
+			attendees, err := classes.Attendees(context.Background(), cliniko.GroupAppointmentId(classId))
+			if err != nil {
+				t.Fatalf("Attendees: %v", err)
+			}
+			if len(attendees) != tt.wantActive {
+				t.Errorf("%d active attendees, want %d", len(attendees), tt.wantActive)
+			}
+		})
+	}
+}
+
+// seedPatients stores a patient for each first name
+func seedPatients(t *testing.T, srv *clinikotest.Server, names ...string) []string {
+	t.Helper()
+
+	var ids []string
+	for _, name := range names {
+		id, err := srv.Seed("patients", map[string]any{"first_name": name, "last_name": "Doe"})
+		if err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// TestCancelPromotes cancels an attendee of a full class, the
+// first patient of the waitlist takes the freed place
+func TestCancelPromotes(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	classId, err := srv.Seed("group_appointments", map[string]any{
+		"starts_at":     "2024-01-01T09:00:00Z",
+		"max_attendees": 1,
+	})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	patientIds := seedPatients(t, srv, "Jane", "John", "Joan")
+	id := cliniko.GroupAppointmentId(classId)
+
+	ctx := context.Background()
+	classes := client.NewGroupClasses()
+	first, err := classes.Enroll(ctx, id, cliniko.PatientId(patientIds[0]), nil)
+	if err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	for _, patientId := range patientIds[1:] {
+		attendee, waitlisted, err := classes.EnrollOrWaitlist(ctx, id, cliniko.PatientId(patientId), nil)
+		if err != nil || attendee != nil || !waitlisted {
+			t.Fatalf("EnrollOrWaitlist(%s) = %v, %v, %v, want the patient waitlisted", patientId, attendee, waitlisted, err)
+		}
+	}
+
+	promoted, err := classes.Cancel(ctx, cliniko.AttendeeId(*first.Id),
+		cliniko.AttendeeCancelCancellationReasonSick, "")
+	if err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if promoted == nil {
+		t.Fatal("Cancel promoted nobody")
+	}
+	if patientId, _ := promoted.PatientId(); string(patientId) != patientIds[1] {
+		t.Errorf("promoted patient %s, want %s", patientId, patientIds[1])
+	}
+
+	waitlist := classes.Waitlist(id)
+	if len(waitlist) != 1 || string(waitlist[0].PatientId) != patientIds[2] {
+		t.Errorf("waitlist = %+v, want only patient %s", waitlist, patientIds[2])
+	}
+	attendees, err := classes.Attendees(ctx, id)
+	if err != nil {
+		t.Fatalf("Attendees: %v", err)
+	}
+	if len(attendees) != 1 || *attendees[0].Id != *promoted.Id {
+		t.Errorf("attendees = %+v, want only the promoted attendee", attendees)
+	}
+}
+
+// TestOccupancyBetween counts the attendees and waitlists of the
+// classes in a range
+func TestOccupancyBetween(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	classIds := map[string]string{}
+	for _, class := range []struct {
+		name     string
+		startsAt string
+		record   map[string]any
+	}{
+		{"later", "2024-01-01T11:00:00Z", map[string]any{"max_attendees": 2}},
+		{"earlier", "2024-01-01T09:00:00Z", map[string]any{}},
+		{"archived", "2024-01-01T10:00:00Z", map[string]any{"archived_at": "2023-12-01T00:00:00Z"}},
+		{"outside", "2024-01-02T09:00:00Z", map[string]any{}},
+	} {
+		class.record["starts_at"] = class.startsAt
+		if classIds[class.name], err = srv.Seed("group_appointments", class.record); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+	patientIds := seedPatients(t, srv, "Jane", "John", "Joan")
+	for _, patientId := range patientIds[:2] {
+		if _, err := srv.Seed("attendees", map[string]any{
+			"booking_id": classIds["later"],
+			"patient_id": patientId,
+		}); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+	if _, err := srv.Seed("attendees", map[string]any{
+		"booking_id":   classIds["earlier"],
+		"patient_id":   patientIds[0],
+		"cancelled_at": "2023-12-01T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	classes := client.NewGroupClasses()
+	err = classes.AddToWaitlist(cliniko.GroupAppointmentId(classIds["later"]), cliniko.WaitlistEntry{
+		PatientId: cliniko.PatientId(patientIds[2]),
+	})
+	if err != nil {
+		t.Fatalf("AddToWaitlist: %v", err)
+	}
+
+	occupancies, err := classes.OccupancyBetween(context.Background(),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("OccupancyBetween: %v", err)
+	}
+
+	want := []struct {
+		class      string
+		attendees  int
+		capacity   int
+		waitlisted int
+		free       int
+	}{
+		{"earlier", 0, -1, 0, -1},
+		{"later", 2, 2, 1, 0},
+	}
+	if len(occupancies) != len(want) {
+		t.Fatalf("%d occupancies, want %d", len(occupancies), len(want))
+	}
+	for i, w := range want {
+		t.Run(w.class, func(t *testing.T) {
+			o := occupancies[i]
+			if *o.Appointment.Id != classIds[w.class] {
+				t.Errorf("appointment %s, want %s", *o.Appointment.Id, classIds[w.class])
+			}
+			if o.Attendees != w.attendees || o.Capacity != w.capacity ||
+				o.Waitlisted != w.waitlisted || o.Free() != w.free {
+				t.Errorf("occupancy = %d/%d, %d waitlisted, %d free, want %d/%d, %d waitlisted, %d free",
+					o.Attendees, o.Capacity, o.Waitlisted, o.Free(),
+					w.attendees, w.capacity, w.waitlisted, w.free)
+			}
+		})
+	}
+}
+
+// TestMoveArchiveFails moves an attendee whose archive request
+// fails, the attendee created in the target is removed again
+func TestMoveArchiveFails(t *testing.T) {
+	tests := []struct {
+		name      string
+		failUndo  bool
+		wantMoved bool
+		wantFrom  int
+		wantTo    int
+	}{
+		{"undone", false, false, 1, 0},
+		{"undo fails", true, true, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := clinikotest.NewServer()
+			defer srv.Close()
+			client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+			if err != nil {
+				t.Fatalf("NewClinikoClient: %v", err)
+			}
+
+			classIds := make([]string, 2)
+			for i := range classIds {
+				if classIds[i], err = srv.Seed("group_appointments", map[string]any{
+					"starts_at": "2024-01-01T09:00:00Z",
+				}); err != nil {
+					t.Fatalf("Seed: %v", err)
+				}
+			}
+			patientIds := seedPatients(t, srv, "Jane")
+			attendeeId, err := srv.Seed("attendees", map[string]any{
+				"booking_id": classIds[0],
+				"patient_id": patientIds[0],
+			})
+			if err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+
+			failArchive := func(ctx context.Context, req *http.Request) error {
+				if req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/archive") {
+					return nil
+				}
+				if tt.failUndo || strings.HasSuffix(req.URL.Path, "/attendees/"+attendeeId+"/archive") {
+					return errors.New("archive failed")
+				}
+				return nil
+			}
+
+			ctx := context.Background()
+			classes := client.NewGroupClasses()
+			result, err := classes.Move(ctx,
+				cliniko.GroupAppointmentId(classIds[0]), cliniko.GroupAppointmentId(classIds[1]), nil, failArchive)
+			if err == nil {
+				t.Fatal("Move did not fail")
+			}
+			if _, moved := result.Moved[cliniko.AttendeeId(attendeeId)]; moved != tt.wantMoved {
+				t.Errorf("attendee moved = %v, want %v", moved, tt.wantMoved)
+			}
+
+			for i, want := range []int{tt.wantFrom, tt.wantTo} {
+				attendees, err := classes.Attendees(ctx, cliniko.GroupAppointmentId(classIds[i]))
+				if err != nil {
+					t.Fatalf("Attendees: %v", err)
+				}
+				if len(attendees) != want {
+					t.Errorf("group appointment %s has %d attendees, want %d", classIds[i], len(attendees), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1314 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import "context"
+
+// AppointmentTypeId is the id of an AppointmentType
+type AppointmentTypeId string
+
+// AttendeeId is the id of an Attendee
+type AttendeeId string
+
+// AvailabilityBlockId is the id of an AvailabilityBlock
+type AvailabilityBlockId string
+
+// BillableItemId is the id of a BillableItem
+type BillableItemId string
+
+// BookingId is the id of a Booking
+type BookingId string
+
+// BusinessId is the id of a Business
+type BusinessId string
+
+// CommunicationId is the id of a Communication
+type CommunicationId string
+
+// ConcessionPriceId is the id of a ConcessionPrice
+type ConcessionPriceId string
+
+// ConcessionTypeId is the id of a ConcessionType
+type ConcessionTypeId string
+
+// ContactId is the id of a Contact
+type ContactId string
+
+// DailyAvailabilityId is the id of a DailyAvailability
+type DailyAvailabilityId string
+
+// GroupAppointmentId is the id of a GroupAppointment
+type GroupAppointmentId string
+
+// IndividualAppointmentId is the id of an IndividualAppointment
+type IndividualAppointmentId string
+
+// InvoiceId is the id of an Invoice
+type InvoiceId string
+
+// InvoiceItemId is the id of an InvoiceItem
+type InvoiceItemId string
+
+// MedicalAlertId is the id of a MedicalAlert
+type MedicalAlertId string
+
+// PatientId is the id of a Patient
+type PatientId string
+
+// PatientAttachmentId is the id of a PatientAttachment
+type PatientAttachmentId string
+
+// PatientCaseId is the id of a PatientCase
+type PatientCaseId string
+
+// PatientFormId is the id of a PatientForm
+type PatientFormId string
+
+// PatientFormTemplateId is the id of a PatientFormTemplate
+type PatientFormTemplateId string
+
+// PractitionerId is the id of a Practitioner
+type PractitionerId string
+
+// PractitionerReferenceNumberId is the id of a PractitionerReferenceNumber
+type PractitionerReferenceNumberId string
+
+// ProductId is the id of a Product
+type ProductId string
+
+// ProductSupplierId is the id of a ProductSupplier
+type ProductSupplierId string
+
+// ReferralSourceTypeId is the id of a ReferralSourceType
+type ReferralSourceTypeId string
+
+// StockAdjustmentId is the id of a StockAdjustment
+type StockAdjustmentId string
+
+// TaxId is the id of a Tax
+type TaxId string
+
+// TreatmentNoteId is the id of a TreatmentNote
+type TreatmentNoteId string
+
+// TreatmentNoteTemplateId is the id of a TreatmentNoteTemplate
+type TreatmentNoteTemplateId string
+
+// UnavailableBlockId is the id of a UnavailableBlock
+type UnavailableBlockId string
+
+// UserId is the id of a User
+type UserId string
+
+// BillableItemId returns the id of the BillableItem linked by BillableItem
+func (at AppointmentType) BillableItemId() (BillableItemId, bool) {
+	id, ok := linkId(at.BillableItem, "billable_items")
+	return BillableItemId(id), ok
+}
+
+// ProductId returns the id of the Product linked by Product
+func (at AppointmentType) ProductId() (ProductId, bool) {
+	id, ok := linkId(at.Product, "products")
+	return ProductId(id), ok
+}
+
+// TreatmentNoteTemplateId returns the id of the TreatmentNoteTemplate linked by TreatmentNoteTemplate
+func (at AppointmentType) TreatmentNoteTemplateId() (TreatmentNoteTemplateId, bool) {
+	id, ok := linkId(at.TreatmentNoteTemplate, "treatment_note_templates")
+	return TreatmentNoteTemplateId(id), ok
+}
+
+// BookingId returns the id of the Booking linked by Booking
+func (a Attendee) BookingId() (BookingId, bool) {
+	id, ok := linkId(a.Booking, "bookings")
+	return BookingId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (a Attendee) PatientId() (PatientId, bool) {
+	id, ok := linkId(a.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PatientCaseId returns the id of the PatientCase linked by PatientCase
+func (a Attendee) PatientCaseId() (PatientCaseId, bool) {
+	id, ok := linkId(a.PatientCase, "patient_cases")
+	return PatientCaseId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (ab AvailabilityBlock) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(ab.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (ab AvailabilityBlock) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(ab.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// TaxId returns the id of the Tax linked by Tax
+func (bi BillableItem) TaxId() (TaxId, bool) {
+	id, ok := linkId(bi.Tax, "taxes")
+	return TaxId(id), ok
+}
+
+// BillableItemId returns the id of the BillableItem linked by BillableItem
+func (cp ConcessionPrice) BillableItemId() (BillableItemId, bool) {
+	id, ok := linkId(cp.BillableItem, "billable_items")
+	return BillableItemId(id), ok
+}
+
+// ConcessionTypeId returns the id of the ConcessionType linked by ConcessionType
+func (cp ConcessionPrice) ConcessionTypeId() (ConcessionTypeId, bool) {
+	id, ok := linkId(cp.ConcessionType, "concession_types")
+	return ConcessionTypeId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (da DailyAvailability) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(da.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (da DailyAvailability) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(da.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// AttendeeId returns the id of the Attendee linked by Attendee
+func (ec EmailCommunication) AttendeeId() (AttendeeId, bool) {
+	id, ok := linkId(ec.Attendee, "attendees")
+	return AttendeeId(id), ok
+}
+
+// BookingId returns the id of the Booking linked by Booking
+func (ec EmailCommunication) BookingId() (BookingId, bool) {
+	id, ok := linkId(ec.Booking, "bookings")
+	return BookingId(id), ok
+}
+
+// InvoiceId returns the id of the Invoice linked by Invoice
+func (ec EmailCommunication) InvoiceId() (InvoiceId, bool) {
+	id, ok := linkId(ec.Invoice, "invoices")
+	return InvoiceId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (ec EmailCommunication) PatientId() (PatientId, bool) {
+	id, ok := linkId(ec.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (fpe FullPatientExport) PatientId() (PatientId, bool) {
+	id, ok := linkId(fpe.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// UserId returns the id of the User linked by User
+func (fpe FullPatientExport) UserId() (UserId, bool) {
+	id, ok := linkId(fpe.User, "users")
+	return UserId(id), ok
+}
+
+// AppointmentTypeId returns the id of the AppointmentType linked by AppointmentType
+func (ga GroupAppointment) AppointmentTypeId() (AppointmentTypeId, bool) {
+	id, ok := linkId(ga.AppointmentType, "appointment_types")
+	return AppointmentTypeId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (ga GroupAppointment) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(ga.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (ga GroupAppointment) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(ga.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// RepeatedFromId returns the id of the GroupAppointment linked by RepeatedFrom
+func (ga GroupAppointment) RepeatedFromId() (GroupAppointmentId, bool) {
+	id, ok := linkId(ga.RepeatedFrom, "group_appointments")
+	return GroupAppointmentId(id), ok
+}
+
+// AppointmentTypeId returns the id of the AppointmentType linked by AppointmentType
+func (ia IndividualAppointment) AppointmentTypeId() (AppointmentTypeId, bool) {
+	id, ok := linkId(ia.AppointmentType, "appointment_types")
+	return AppointmentTypeId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (ia IndividualAppointment) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(ia.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (ia IndividualAppointment) PatientId() (PatientId, bool) {
+	id, ok := linkId(ia.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PatientCaseId returns the id of the PatientCase linked by PatientCase
+func (ia IndividualAppointment) PatientCaseId() (PatientCaseId, bool) {
+	id, ok := linkId(ia.PatientCase, "patient_cases")
+	return PatientCaseId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (ia IndividualAppointment) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(ia.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// RepeatedFromId returns the id of the IndividualAppointment linked by RepeatedFrom
+func (ia IndividualAppointment) RepeatedFromId() (IndividualAppointmentId, bool) {
+	id, ok := linkId(ia.RepeatedFrom, "individual_appointments")
+	return IndividualAppointmentId(id), ok
+}
+
+// AppointmentId returns the id of the Booking linked by Appointment
+func (i Invoice) AppointmentId() (BookingId, bool) {
+	id, ok := linkId(i.Appointment, "appointments")
+	return BookingId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (i Invoice) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(i.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (i Invoice) PatientId() (PatientId, bool) {
+	id, ok := linkId(i.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (i Invoice) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(i.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// BillableItemId returns the id of the BillableItem linked by BillableItem
+func (ii InvoiceItem) BillableItemId() (BillableItemId, bool) {
+	id, ok := linkId(ii.BillableItem, "billable_items")
+	return BillableItemId(id), ok
+}
+
+// InvoiceId returns the id of the Invoice linked by Invoice
+func (ii InvoiceItem) InvoiceId() (InvoiceId, bool) {
+	id, ok := linkId(ii.Invoice, "invoices")
+	return InvoiceId(id), ok
+}
+
+// ProductId returns the id of the Product linked by Product
+func (ii InvoiceItem) ProductId() (ProductId, bool) {
+	id, ok := linkId(ii.Product, "products")
+	return ProductId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (ma MedicalAlert) PatientId() (PatientId, bool) {
+	id, ok := linkId(ma.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (mc MemoCommunication) PatientId() (PatientId, bool) {
+	id, ok := linkId(mc.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// ConcessionTypeId returns the id of the ConcessionType linked by ConcessionType
+func (p Patient) ConcessionTypeId() (ConcessionTypeId, bool) {
+	id, ok := linkId(p.ConcessionType, "concession_types")
+	return ConcessionTypeId(id), ok
+}
+
+// LatestBookingId returns the id of the Booking linked by LatestBooking
+func (p Patient) LatestBookingId() (BookingId, bool) {
+	id, ok := linkId(p.LatestBooking, "bookings")
+	return BookingId(id), ok
+}
+
+// MergedWithPatientId returns the id of the Patient linked by MergedWithPatient
+func (p Patient) MergedWithPatientId() (PatientId, bool) {
+	id, ok := linkId(p.MergedWithPatient, "patients")
+	return PatientId(id), ok
+}
+
+// ReferringDoctorId returns the id of the Contact linked by ReferringDoctor
+func (p Patient) ReferringDoctorId() (ContactId, bool) {
+	id, ok := linkId(p.ReferringDoctor, "contacts")
+	return ContactId(id), ok
+}
+
+// ContactId returns the id of the Contact linked by Contact
+func (pc PatientCase) ContactId() (ContactId, bool) {
+	id, ok := linkId(pc.Contact, "contacts")
+	return ContactId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (pc PatientCase) PatientId() (PatientId, bool) {
+	id, ok := linkId(pc.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// AttendeeId returns the id of the Attendee linked by Attendee
+func (pf PatientForm) AttendeeId() (AttendeeId, bool) {
+	id, ok := linkId(pf.Attendee, "attendees")
+	return AttendeeId(id), ok
+}
+
+// BookingId returns the id of the Booking linked by Booking
+func (pf PatientForm) BookingId() (BookingId, bool) {
+	id, ok := linkId(pf.Booking, "bookings")
+	return BookingId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (pf PatientForm) PatientId() (PatientId, bool) {
+	id, ok := linkId(pf.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// DefaultAppointmentTypeId returns the id of the AppointmentType linked by DefaultAppointmentType
+func (p Practitioner) DefaultAppointmentTypeId() (AppointmentTypeId, bool) {
+	id, ok := linkId(p.DefaultAppointmentType, "appointment_types")
+	return AppointmentTypeId(id), ok
+}
+
+// UserId returns the id of the User linked by User
+func (p Practitioner) UserId() (UserId, bool) {
+	id, ok := linkId(p.User, "users")
+	return UserId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (prn PractitionerReferenceNumber) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(prn.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (prn PractitionerReferenceNumber) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(prn.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// ProductSupplierId returns the id of the ProductSupplier linked by ProductSupplier
+func (p Product) ProductSupplierId() (ProductSupplierId, bool) {
+	id, ok := linkId(p.ProductSupplier, "product_suppliers")
+	return ProductSupplierId(id), ok
+}
+
+// TaxId returns the id of the Tax linked by Tax
+func (p Product) TaxId() (TaxId, bool) {
+	id, ok := linkId(p.Tax, "taxes")
+	return TaxId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (rs ReferralSource) PatientId() (PatientId, bool) {
+	id, ok := linkId(rs.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// ReferralSourceTypeId returns the id of the ReferralSourceType linked by ReferralSourceType
+func (rs ReferralSource) ReferralSourceTypeId() (ReferralSourceTypeId, bool) {
+	id, ok := linkId(rs.ReferralSourceType, "referral_source_types")
+	return ReferralSourceTypeId(id), ok
+}
+
+// AppointmentTypeId returns the id of the AppointmentType linked by AppointmentType
+func (s Service) AppointmentTypeId() (AppointmentTypeId, bool) {
+	id, ok := linkId(s.AppointmentType, "appointment_types")
+	return AppointmentTypeId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (s Service) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(s.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// UserId returns the id of the User linked by User
+func (s Signature) UserId() (UserId, bool) {
+	id, ok := linkId(s.User, "users")
+	return UserId(id), ok
+}
+
+// AttendeeId returns the id of the Attendee linked by Attendee
+func (sc SmsCommunication) AttendeeId() (AttendeeId, bool) {
+	id, ok := linkId(sc.Attendee, "attendees")
+	return AttendeeId(id), ok
+}
+
+// BookingId returns the id of the Booking linked by Booking
+func (sc SmsCommunication) BookingId() (BookingId, bool) {
+	id, ok := linkId(sc.Booking, "bookings")
+	return BookingId(id), ok
+}
+
+// InvoiceId returns the id of the Invoice linked by Invoice
+func (sc SmsCommunication) InvoiceId() (InvoiceId, bool) {
+	id, ok := linkId(sc.Invoice, "invoices")
+	return InvoiceId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (sc SmsCommunication) PatientId() (PatientId, bool) {
+	id, ok := linkId(sc.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// ProductId returns the id of the Product linked by Product
+func (sa StockAdjustment) ProductId() (ProductId, bool) {
+	id, ok := linkId(sa.Product, "products")
+	return ProductId(id), ok
+}
+
+// UserId returns the id of the User linked by User
+func (sa StockAdjustment) UserId() (UserId, bool) {
+	id, ok := linkId(sa.User, "users")
+	return UserId(id), ok
+}
+
+// AttendeeId returns the id of the Attendee linked by Attendee
+func (tn TreatmentNote) AttendeeId() (AttendeeId, bool) {
+	id, ok := linkId(tn.Attendee, "attendees")
+	return AttendeeId(id), ok
+}
+
+// BookingId returns the id of the Booking linked by Booking
+func (tn TreatmentNote) BookingId() (BookingId, bool) {
+	id, ok := linkId(tn.Booking, "bookings")
+	return BookingId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (tn TreatmentNote) PatientId() (PatientId, bool) {
+	id, ok := linkId(tn.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (tn TreatmentNote) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(tn.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// TreatmentNoteTemplateId returns the id of the TreatmentNoteTemplate linked by TreatmentNoteTemplate
+func (tn TreatmentNote) TreatmentNoteTemplateId() (TreatmentNoteTemplateId, bool) {
+	id, ok := linkId(tn.TreatmentNoteTemplate, "treatment_note_templates")
+	return TreatmentNoteTemplateId(id), ok
+}
+
+// BusinessId returns the id of the Business linked by Business
+func (ub UnavailableBlock) BusinessId() (BusinessId, bool) {
+	id, ok := linkId(ub.Business, "businesses")
+	return BusinessId(id), ok
+}
+
+// PractitionerId returns the id of the Practitioner linked by Practitioner
+func (ub UnavailableBlock) PractitionerId() (PractitionerId, bool) {
+	id, ok := linkId(ub.Practitioner, "practitioners")
+	return PractitionerId(id), ok
+}
+
+// RepeatedFromId returns the id of the UnavailableBlock linked by RepeatedFrom
+func (ub UnavailableBlock) RepeatedFromId() (UnavailableBlockId, bool) {
+	id, ok := linkId(ub.RepeatedFrom, "unavailable_blocks")
+	return UnavailableBlockId(id), ok
+}
+
+// PatientId returns the id of the Patient linked by Patient
+func (upa UploadedPatientAttachment) PatientId() (PatientId, bool) {
+	id, ok := linkId(upa.Patient, "patients")
+	return PatientId(id), ok
+}
+
+// UserId returns the id of the User linked by User
+func (upa UploadedPatientAttachment) UserId() (UserId, bool) {
+	id, ok := linkId(upa.User, "users")
+	return UserId(id), ok
+}
+
+// GetAppointmentType fetches the AppointmentType with the given id
+func (c *ClinikoClient) GetAppointmentType(
+	ctx context.Context,
+	id AppointmentTypeId,
+	reqEditors ...RequestEditorFn,
+) (
+	*AppointmentType, error,
+) {
+	rsp, err := c.GetAppointmentTypeGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get appointment type request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetAttendee fetches the Attendee with the given id
+func (c *ClinikoClient) GetAttendee(
+	ctx context.Context,
+	id AttendeeId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	rsp, err := c.GetAttendeeGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get attendee request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetAvailabilityBlock fetches the AvailabilityBlock with the given id
+func (c *ClinikoClient) GetAvailabilityBlock(
+	ctx context.Context,
+	id AvailabilityBlockId,
+	reqEditors ...RequestEditorFn,
+) (
+	*AvailabilityBlock, error,
+) {
+	rsp, err := c.GetAvailabilityBlockGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get availability block request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetBillableItem fetches the BillableItem with the given id
+func (c *ClinikoClient) GetBillableItem(
+	ctx context.Context,
+	id BillableItemId,
+	reqEditors ...RequestEditorFn,
+) (
+	*BillableItem, error,
+) {
+	rsp, err := c.GetBillableItemGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get billable item request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetBooking fetches the Booking with the given id
+func (c *ClinikoClient) GetBooking(
+	ctx context.Context,
+	id BookingId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	rsp, err := c.GetBookingGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get booking request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetBusiness fetches the Business with the given id
+func (c *ClinikoClient) GetBusiness(
+	ctx context.Context,
+	id BusinessId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	rsp, err := c.GetBusinessGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get business request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetCommunication fetches the Communication with the given id
+func (c *ClinikoClient) GetCommunication(
+	ctx context.Context,
+	id CommunicationId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Communication, error,
+) {
+	rsp, err := c.GetCommunicationGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get communication request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetConcessionPrice fetches the ConcessionPrice with the given id
+func (c *ClinikoClient) GetConcessionPrice(
+	ctx context.Context,
+	id ConcessionPriceId,
+	reqEditors ...RequestEditorFn,
+) (
+	*ConcessionPrice, error,
+) {
+	rsp, err := c.GetConcessionPriceGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get concession price request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetConcessionType fetches the ConcessionType with the given id
+func (c *ClinikoClient) GetConcessionType(
+	ctx context.Context,
+	id ConcessionTypeId,
+	reqEditors ...RequestEditorFn,
+) (
+	*ConcessionType, error,
+) {
+	rsp, err := c.GetConcessionTypeGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get concession type request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetContact fetches the Contact with the given id
+func (c *ClinikoClient) GetContact(
+	ctx context.Context,
+	id ContactId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Contact, error,
+) {
+	rsp, err := c.GetContactGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get contact request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetDailyAvailability fetches the DailyAvailability with the given id
+func (c *ClinikoClient) GetDailyAvailability(
+	ctx context.Context,
+	id DailyAvailabilityId,
+	reqEditors ...RequestEditorFn,
+) (
+	*DailyAvailability, error,
+) {
+	rsp, err := c.GetDailyAvailabilityGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get daily availability request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetGroupAppointment fetches the GroupAppointment with the given id
+func (c *ClinikoClient) GetGroupAppointment(
+	ctx context.Context,
+	id GroupAppointmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	*GroupAppointment, error,
+) {
+	rsp, err := c.GetGroupAppointmentGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get group appointment request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetIndividualAppointment fetches the IndividualAppointment with the given id
+func (c *ClinikoClient) GetIndividualAppointment(
+	ctx context.Context,
+	id IndividualAppointmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	*IndividualAppointment, error,
+) {
+	rsp, err := c.GetIndividualAppointmentGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get individual appointment request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetInvoice fetches the Invoice with the given id
+func (c *ClinikoClient) GetInvoice(
+	ctx context.Context,
+	id InvoiceId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Invoice, error,
+) {
+	rsp, err := c.GetInvoiceGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get invoice request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetInvoiceItem fetches the InvoiceItem with the given id
+func (c *ClinikoClient) GetInvoiceItem(
+	ctx context.Context,
+	id InvoiceItemId,
+	reqEditors ...RequestEditorFn,
+) (
+	*InvoiceItem, error,
+) {
+	rsp, err := c.GetInvoiceItemGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get invoice item request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetMedicalAlert fetches the MedicalAlert with the given id
+func (c *ClinikoClient) GetMedicalAlert(
+	ctx context.Context,
+	id MedicalAlertId,
+	reqEditors ...RequestEditorFn,
+) (
+	*MedicalAlert, error,
+) {
+	rsp, err := c.GetMedicalAlertGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get medical alert request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPatient fetches the Patient with the given id
+func (c *ClinikoClient) GetPatient(
+	ctx context.Context,
+	id PatientId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	rsp, err := c.GetPatientGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPatientAttachment fetches the PatientAttachment with the given id
+func (c *ClinikoClient) GetPatientAttachment(
+	ctx context.Context,
+	id PatientAttachmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientAttachment, error,
+) {
+	rsp, err := c.GetPatientAttachmentGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient attachment request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPatientCase fetches the PatientCase with the given id
+func (c *ClinikoClient) GetPatientCase(
+	ctx context.Context,
+	id PatientCaseId,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientCase, error,
+) {
+	rsp, err := c.GetPatientCaseGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient case request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPatientForm fetches the PatientForm with the given id
+func (c *ClinikoClient) GetPatientForm(
+	ctx context.Context,
+	id PatientFormId,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientForm, error,
+) {
+	rsp, err := c.GetPatientFormGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient form request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPatientFormTemplate fetches the PatientFormTemplate with the given id
+func (c *ClinikoClient) GetPatientFormTemplate(
+	ctx context.Context,
+	id PatientFormTemplateId,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientFormTemplate, error,
+) {
+	rsp, err := c.GetPatientFormTemplateGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get patient form template request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPractitioner fetches the Practitioner with the given id
+func (c *ClinikoClient) GetPractitioner(
+	ctx context.Context,
+	id PractitionerId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	rsp, err := c.GetPractitionerGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get practitioner request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetPractitionerReferenceNumber fetches the PractitionerReferenceNumber with the given id
+func (c *ClinikoClient) GetPractitionerReferenceNumber(
+	ctx context.Context,
+	id PractitionerReferenceNumberId,
+	reqEditors ...RequestEditorFn,
+) (
+	*PractitionerReferenceNumber, error,
+) {
+	rsp, err := c.GetPractitionerReferenceNumberGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get practitioner reference number request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetProduct fetches the Product with the given id
+func (c *ClinikoClient) GetProduct(
+	ctx context.Context,
+	id ProductId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Product, error,
+) {
+	rsp, err := c.GetProductGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get product request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetProductSupplier fetches the ProductSupplier with the given id
+func (c *ClinikoClient) GetProductSupplier(
+	ctx context.Context,
+	id ProductSupplierId,
+	reqEditors ...RequestEditorFn,
+) (
+	*ProductSupplier, error,
+) {
+	rsp, err := c.GetProductSupplierGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get product supplier request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetReferralSourceType fetches the ReferralSourceType with the given id
+func (c *ClinikoClient) GetReferralSourceType(
+	ctx context.Context,
+	id ReferralSourceTypeId,
+	reqEditors ...RequestEditorFn,
+) (
+	*ReferralSourceType, error,
+) {
+	rsp, err := c.GetReferralSourceTypeGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get referral source type request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetStockAdjustment fetches the StockAdjustment with the given id
+func (c *ClinikoClient) GetStockAdjustment(
+	ctx context.Context,
+	id StockAdjustmentId,
+	reqEditors ...RequestEditorFn,
+) (
+	*StockAdjustment, error,
+) {
+	rsp, err := c.GetStockAdjustmentGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get stock adjustment request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetTax fetches the Tax with the given id
+func (c *ClinikoClient) GetTax(
+	ctx context.Context,
+	id TaxId,
+	reqEditors ...RequestEditorFn,
+) (
+	*Tax, error,
+) {
+	rsp, err := c.GetTaxGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get tax request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetTreatmentNote fetches the TreatmentNote with the given id
+func (c *ClinikoClient) GetTreatmentNote(
+	ctx context.Context,
+	id TreatmentNoteId,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNote, error,
+) {
+	rsp, err := c.GetTreatmentNoteGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get treatment note request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetTreatmentNoteTemplate fetches the TreatmentNoteTemplate with the given id
+func (c *ClinikoClient) GetTreatmentNoteTemplate(
+	ctx context.Context,
+	id TreatmentNoteTemplateId,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNoteTemplate, error,
+) {
+	rsp, err := c.GetTreatmentNoteTemplateGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get treatment note template request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetUnavailableBlock fetches the UnavailableBlock with the given id
+func (c *ClinikoClient) GetUnavailableBlock(
+	ctx context.Context,
+	id UnavailableBlockId,
+	reqEditors ...RequestEditorFn,
+) (
+	*UnavailableBlock, error,
+) {
+	rsp, err := c.GetUnavailableBlockGetWithResponse(ctx, string(id), nil, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get unavailable block request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
+
+// GetUser fetches the User with the given id
+func (c *ClinikoClient) GetUser(
+	ctx context.Context,
+	id UserId,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	rsp, err := c.GetUserGetWithResponse(ctx, string(id), reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get user request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
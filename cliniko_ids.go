@@ -0,0 +1,63 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// apiVersionPath is the last segment of the path the API
+// is served under, e.g. /v1 of https://api.au1.cliniko.com/v1
+const apiVersionPath = "/v1/"
+
+// splitLink splits a links.self URL into collection and id, e.g.
+// "patients" and "1". With a server the link must belong to it,
+// see ClinikoClient.ParseLink. Without one, as for the links
+// embedded in models, the host is not checked but the record
+// must still sit directly below the API version path.
+func splitLink(link string, server *url.URL) (string, string, error) {
+	target, err := url.Parse(link)
+	if err != nil {
+		return "", "", fmt.Errorf("cliniko: invalid link %q: %w", link, err)
+	}
+
+	var prefix string
+	if server != nil {
+		prefix = strings.TrimSuffix(server.Path, "/") + "/"
+		if !strings.EqualFold(target.Host, server.Host) ||
+			target.Scheme != server.Scheme ||
+			!strings.HasPrefix(target.Path, prefix) {
+			return "", "", fmt.Errorf("cliniko: link %q does not belong to %s", link, server)
+		}
+	} else {
+		version := strings.Index(target.Path, apiVersionPath)
+		if version < 0 || target.Host == "" {
+			return "", "", fmt.Errorf("cliniko: link %q is not an API link", link)
+		}
+		prefix = target.Path[:version+len(apiVersionPath)]
+	}
+
+	segments := strings.Split(strings.TrimPrefix(target.Path, prefix), "/")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("cliniko: link %q does not point to a single record", link)
+	}
+	return segments[0], segments[1], nil
+}
+
+// linkId returns the id of a linked record if the
+// link is set and points into the given collection
+func linkId(field *linkField, collection string) (string, bool) {
+	link := selfLink(field)
+	if link == nil {
+		return "", false
+	}
+
+	linked, id, err := splitLink(*link, nil)
+	if err != nil || linked != collection {
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,68 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import "testing"
+
+func TestParseLink(t *testing.T) {
+	client, err := NewClinikoClient("MS0xLWFiYw-au1", "vendor", "vendor email")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	tests := []struct {
+		link       string
+		collection string
+		// client is whether ClinikoClient.ParseLink and thereby
+		// Resolve accept the link, model is whether the typed
+		// accessors of models do
+		client bool
+		model  bool
+	}{
+		{"https://api.au1.cliniko.com/v1/patients/1", "patients", true, true},
+		{"https://API.au1.cliniko.com/v1/patients/1", "patients", true, true},
+		{"https://api.uk1.cliniko.com/v1/patients/1", "patients", false, true},
+		{"http://127.0.0.1:8080/v1/patients/1", "patients", false, true},
+		{"http://api.au1.cliniko.com/v1/patients/1", "patients", false, true},
+		{"https://api.au1.cliniko.com/v1/patients", "", false, false},
+		{"https://api.au1.cliniko.com/v1/patients/1/invoices", "", false, false},
+		{"https://api.au1.cliniko.com/v2/patients/1", "", false, false},
+		{"https://api.au1.cliniko.com/patients/1", "", false, false},
+		{"/v1/patients/1", "", false, false},
+		{"https://api.au1.cliniko.com/v1/patients/", "", false, false},
+		{"://invalid", "", false, false},
+	}
+
+	for _, tt := range tests {
+		collection, id, err := client.ParseLink(tt.link)
+		if tt.client && (err != nil || collection != tt.collection || id != "1") {
+			t.Errorf("ParseLink(%q) = %q, %q, %v, want %q and 1", tt.link, collection, id, err, tt.collection)
+		}
+		if !tt.client && err == nil {
+			t.Errorf("ParseLink(%q) = %q, %q, want an error", tt.link, collection, id)
+		}
+
+		self := tt.link
+		field := &linkField{}
+		field.Links = &struct {
+			Self *string `json:"self,omitempty"`
+		}{Self: &self}
+		id, ok := linkId(field, "patients")
+		if ok != tt.model || (ok && id != "1") {
+			t.Errorf("linkId(%q) = %q, %v, want %v", tt.link, id, ok, tt.model)
+		}
+	}
+}
+
+func TestInvoiceAppointmentId(t *testing.T) {
+	self := "https://api.au1.cliniko.com/v1/appointments/12"
+	invoice := Invoice{Appointment: &linkField{}}
+	invoice.Appointment.Links = &struct {
+		Self *string `json:"self,omitempty"`
+	}{Self: &self}
+
+	if id, ok := invoice.AppointmentId(); !ok || id != "12" {
+		t.Errorf("AppointmentId = %q, %v, want 12", id, ok)
+	}
+}
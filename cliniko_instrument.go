@@ -0,0 +1,516 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Names of the metrics recorded by an Instrumentation
+const (
+	// MetricRequests counts finished requests by
+	// "operation" and "status", "error" for failed ones
+	MetricRequests = "cliniko_requests_total"
+	// MetricRequestDuration observes the seconds until
+	// the response headers arrived by "operation"
+	MetricRequestDuration = "cliniko_request_duration_seconds"
+	// MetricResponseSize observes the bytes of
+	// response bodies read by "operation"
+	MetricResponseSize = "cliniko_response_size_bytes"
+	// MetricRetries counts repeated requests by "operation"
+	MetricRetries = "cliniko_retries_total"
+	// MetricRateLimitRemaining is the number of requests
+	// left in the current rate limit window
+	MetricRateLimitRemaining = "cliniko_rate_limit_remaining"
+)
+
+// maxLoggedBody is the largest body that is redacted
+// and logged, larger bodies are only logged by size
+const maxLoggedBody = 64 << 10
+
+// redacted replaces values that may contain PHI
+const redacted = "[REDACTED]"
+
+// Metrics receives the measurements of an Instrumentation.
+// Each method maps to a Prometheus CounterVec, HistogramVec
+// or GaugeVec with the given labels. Implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	AddCounter(name string, labels map[string]string, value float64)
+	ObserveHistogram(name string, labels map[string]string, value float64)
+	SetGauge(name string, labels map[string]string, value float64)
+}
+
+// Tracer starts spans, it is a subset of the
+// OpenTelemetry trace.Tracer that is easy to adapt
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes map[string]any) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	SetAttributes(attributes map[string]any)
+	RecordError(err error)
+	End()
+}
+
+// Instrumentation logs, measures and traces requests. Wrap the
+// outermost Doer with NewInstrumentedDoer, so that a span covers
+// all attempts of a call, and set RetryDoer.OnRetry to OnRetry:
+//
+//	instrumentation := &Instrumentation{Logger: slog.Default()}
+//	retry := NewRetryDoer(NewRateLimitedDoer(nil, 0), DefaultRetryPolicy())
+//	retry.OnRetry = instrumentation.OnRetry
+//	client, err := NewClinikoClientWithOptions(token, vendor, email,
+//		WithHTTPDoer(NewInstrumentedDoer(retry, instrumentation)))
+//
+// URLs and bodies are redacted with RedactURL and RedactBody
+// before they are logged or added to spans.
+type Instrumentation struct {
+	// Logger receives a record per request, nil disables logging
+	Logger *slog.Logger
+	// Metrics receives the measurements, nil disables metrics
+	Metrics Metrics
+	// Tracer starts a span per request, nil disables tracing
+	Tracer Tracer
+	// Budget reports the rate limit headroom, it defaults to
+	// the Doer given to NewInstrumentedDoer if that has one
+	Budget RateLimitBudget
+	// LogBodies adds the redacted request and response bodies
+	// to the records if the Logger is enabled for debug level
+	LogBodies bool
+}
+
+type observationContextKey struct{}
+
+// observation tracks a single call from the InstrumentedDoer
+// sending it to the closing of the response body
+type observation struct {
+	operation string
+	start     time.Time
+	span      Span
+	retries   atomic.Int64
+}
+
+// OnRetry counts a repeated request,
+// assign it to RetryDoer.OnRetry
+func (i *Instrumentation) OnRetry(req *http.Request, attempt int, rsp *http.Response, err error) {
+	o, ok := req.Context().Value(observationContextKey{}).(*observation)
+	if !ok {
+		return
+	}
+
+	o.retries.Add(1)
+	if i.Metrics != nil {
+		i.Metrics.AddCounter(MetricRetries, map[string]string{"operation": o.operation}, 1)
+	}
+
+	if i.Logger != nil {
+		attrs := []slog.Attr{
+			slog.String("operation", o.operation),
+			slog.Int("attempt", attempt),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else if rsp != nil {
+			attrs = append(attrs, slog.Int("status", rsp.StatusCode))
+		}
+		i.Logger.LogAttrs(req.Context(), slog.LevelDebug, "cliniko request retried", attrs...)
+	}
+}
+
+// observe returns the request with a new observation
+// and, if a Tracer is set, the context of its span
+func (i *Instrumentation) observe(req *http.Request) *http.Request {
+	o := &observation{
+		operation: OperationName(req),
+		start:     time.Now(),
+	}
+
+	ctx := req.Context()
+	if i.Tracer != nil {
+		ctx, o.span = i.Tracer.Start(ctx, "cliniko "+o.operation, map[string]any{
+			"cliniko.operation":   o.operation,
+			"http.request.method": req.Method,
+			"url.full":            RedactURL(req.URL),
+		})
+	}
+	return req.WithContext(context.WithValue(ctx, observationContextKey{}, o))
+}
+
+// InstrumentedDoer wraps a HttpRequestDoer and reports every
+// request to an Instrumentation once its body has been closed.
+// Requests that already carry an observation, e.g. because two
+// InstrumentedDoers are stacked, are reported only once.
+type InstrumentedDoer struct {
+	doer            HttpRequestDoer
+	instrumentation *Instrumentation
+}
+
+// NewInstrumentedDoer creates an InstrumentedDoer that sends
+// requests through doer. A nil doer defaults to an http.Client
+// using CheckRedirect.
+func NewInstrumentedDoer(
+	doer HttpRequestDoer,
+	instrumentation *Instrumentation,
+) *InstrumentedDoer {
+	if doer == nil {
+		doer = newHTTPClient()
+	}
+	if budget, ok := FindRateLimitBudget(doer); ok && instrumentation.Budget == nil {
+		instrumentation.Budget = budget
+	}
+
+	return &InstrumentedDoer{
+		doer:            doer,
+		instrumentation: instrumentation,
+	}
+}
+
+// Unwrap returns the Doer requests are sent through
+func (d *InstrumentedDoer) Unwrap() HttpRequestDoer {
+	return d.doer
+}
+
+// Do sends the request and implements HttpRequestDoer
+func (d *InstrumentedDoer) Do(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Value(observationContextKey{}).(*observation); ok {
+		return d.doer.Do(req)
+	}
+
+	req = d.instrumentation.observe(req)
+	o := req.Context().Value(observationContextKey{}).(*observation)
+
+	var requestBody []byte
+	// only JSON bodies are logged, rewinding e.g. a streamed
+	// upload would stop the body that is about to be sent
+	if d.instrumentation.logsBodies(req.Context()) && req.GetBody != nil &&
+		strings.Contains(req.Header.Get("Content-Type"), "json") {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			_ = body.Close()
+		}
+	}
+
+	rsp, err := d.doer.Do(req)
+	latency := time.Since(o.start)
+	if err != nil {
+		d.instrumentation.finish(req, o, nil, latency, 0, requestBody, nil, err)
+		return nil, err
+	}
+
+	rsp.Body = &observedBody{
+		ReadCloser:      rsp.Body,
+		instrumentation: d.instrumentation,
+		req:             req,
+		rsp:             rsp,
+		o:               o,
+		latency:         latency,
+		requestBody:     requestBody,
+		logBody:         d.instrumentation.logsBodies(req.Context()),
+	}
+	return rsp, nil
+}
+
+// logsBodies reports whether bodies are logged
+func (i *Instrumentation) logsBodies(ctx context.Context) bool {
+	return i.LogBodies && i.Logger != nil && i.Logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// finish reports a request once it is done
+func (i *Instrumentation) finish(
+	req *http.Request,
+	o *observation,
+	rsp *http.Response,
+	latency time.Duration,
+	size int64,
+	requestBody []byte,
+	responseBody []byte,
+	err error,
+) {
+	ctx := req.Context()
+	status := "error"
+	if rsp != nil {
+		status = strconv.Itoa(rsp.StatusCode)
+	}
+
+	remaining := -1
+	if i.Budget != nil {
+		remaining = i.Budget.Usage().Remaining
+	}
+
+	if i.Metrics != nil {
+		operation := map[string]string{"operation": o.operation}
+		i.Metrics.AddCounter(MetricRequests, map[string]string{
+			"operation": o.operation,
+			"status":    status,
+		}, 1)
+		i.Metrics.ObserveHistogram(MetricRequestDuration, operation, latency.Seconds())
+		if rsp != nil {
+			i.Metrics.ObserveHistogram(MetricResponseSize, operation, float64(size))
+		}
+		if remaining >= 0 {
+			i.Metrics.SetGauge(MetricRateLimitRemaining, map[string]string{}, float64(remaining))
+		}
+	}
+
+	if o.span != nil {
+		attributes := map[string]any{
+			"cliniko.retries":         o.retries.Load(),
+			"http.response.body.size": size,
+		}
+		if rsp != nil {
+			attributes["http.response.status_code"] = rsp.StatusCode
+		}
+		o.span.SetAttributes(attributes)
+		if err != nil {
+			o.span.RecordError(err)
+		}
+		o.span.End()
+	}
+
+	if i.Logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("operation", o.operation),
+		slog.String("method", req.Method),
+		slog.String("url", RedactURL(req.URL)),
+		slog.String("status", status),
+		slog.Duration("latency", latency),
+		slog.Int64("retries", o.retries.Load()),
+		slog.Int64("size", size),
+	}
+	if remaining >= 0 {
+		attrs = append(attrs, slog.Int("rate_limit_remaining", remaining))
+	}
+	switch {
+	case err != nil:
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	case rsp.StatusCode >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+	if i.logsBodies(ctx) {
+		attrs = append(attrs,
+			slog.String("request_body", loggedBody(requestBody)),
+			slog.String("response_body", loggedBody(responseBody)),
+		)
+	}
+	i.Logger.LogAttrs(ctx, level, "cliniko request", attrs...)
+}
+
+// observedBody counts the bytes read from a response body
+// and finishes the observation when it is closed
+type observedBody struct {
+	io.ReadCloser
+
+	instrumentation *Instrumentation
+	req             *http.Request
+	rsp             *http.Response
+	o               *observation
+	latency         time.Duration
+	requestBody     []byte
+	logBody         bool
+
+	size     int64
+	captured bytes.Buffer
+	once     sync.Once
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	if b.logBody && b.captured.Len() <= maxLoggedBody {
+		b.captured.Write(p[:n])
+	}
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.instrumentation.finish(b.req, b.o, b.rsp, b.latency, b.size, b.requestBody, b.captured.Bytes(), nil)
+	})
+	return err
+}
+
+// loggedBody returns the redacted body for a log record
+func loggedBody(body []byte) string {
+	if len(body) > maxLoggedBody {
+		return strconv.Itoa(len(body)) + " bytes " + redacted
+	}
+	return string(RedactBody(body))
+}
+
+// OperationName returns the name of the Client method sending
+// a request, e.g. "ListPatientsGet", or "" for requests that do
+// not match any endpoint. Pages following links.next are named
+// after the list endpoint.
+func OperationName(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	var (
+		best     string
+		length   int
+		literals int
+	)
+	for _, route := range operationRoutes {
+		if route.method != req.Method {
+			continue
+		}
+
+		pattern := strings.Split(strings.TrimPrefix(route.path, "/"), "/")
+		if len(pattern) > len(segments) || len(pattern) < length {
+			continue
+		}
+
+		matched, count := matchRoute(pattern, segments[len(segments)-len(pattern):])
+		if !matched || (len(pattern) == length && count <= literals) {
+			continue
+		}
+		best, length, literals = route.name, len(pattern), count
+	}
+	return best
+}
+
+// operationRoute is an endpoint of the API
+type operationRoute struct {
+	method string
+	path   string
+	name   string
+}
+
+// matchRoute matches path segments against a pattern and
+// returns the number of literal segments that matched
+func matchRoute(pattern []string, segments []string) (bool, int) {
+	literals := 0
+	for i, part := range pattern {
+		switch {
+		case part == "*" && segments[i] != "":
+		case part == segments[i]:
+			literals++
+		default:
+			return false, 0
+		}
+	}
+	return true, literals
+}
+
+// safeField reports whether the value of a field can be
+// logged, i.e. it is an id, a timestamp or a pagination field
+func safeField(name string) bool {
+	switch name {
+	case "id", "self", "next", "previous", "total_entries", "page", "per_page", "sort", "order":
+		return true
+	}
+	return strings.HasSuffix(name, "_id") ||
+		strings.HasSuffix(name, "_ids") ||
+		strings.HasSuffix(name, "_at")
+}
+
+// RedactURL returns the URL with all query values that may
+// contain PHI replaced, e.g. the value of q[]=last_name:=Smith.
+// Paths only contain ids and are kept.
+func RedactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	query := u.Query()
+	for name, values := range query {
+		for i, value := range values {
+			query[name][i] = redactQueryValue(name, value)
+		}
+	}
+
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return copied.String()
+}
+
+func redactQueryValue(name string, value string) string {
+	if name != "q[]" {
+		if safeField(name) || name == "from" || name == "to" {
+			return value
+		}
+		return redacted
+	}
+
+	field, filter, ok := strings.Cut(value, ":")
+	if !ok {
+		return redacted
+	}
+	if safeField(field) {
+		return value
+	}
+
+	operator := filter[:len(filter)-len(strings.TrimLeft(filter, "=!<>~*"))]
+	return field + ":" + operator + redacted
+}
+
+// RedactBody returns a JSON body with the values of all fields
+// that may contain PHI replaced, keeping ids, timestamps and
+// links redacted with RedactURL. Bodies that are not JSON are
+// replaced completely.
+func RedactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []byte(redacted)
+	}
+
+	// links are logged as they are, without escaping "&"
+	var redactedBody bytes.Buffer
+	encoder := json.NewEncoder(&redactedBody)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue("", value)); err != nil {
+		return []byte(redacted)
+	}
+	return bytes.TrimSuffix(redactedBody.Bytes(), []byte("\n"))
+}
+
+func redactValue(field string, value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for name, nested := range value {
+			value[name] = redactValue(name, nested)
+		}
+		return value
+	case []any:
+		for i, nested := range value {
+			value[i] = redactValue(field, nested)
+		}
+		return value
+	case nil:
+		return nil
+	}
+
+	if link, ok := value.(string); ok && (field == "self" || field == "next" || field == "previous") {
+		if u, err := url.Parse(link); err == nil {
+			return RedactURL(u)
+		}
+		return redacted
+	}
+	if safeField(field) {
+		return value
+	}
+	return redacted
+}
@@ -0,0 +1,119 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		query url.Values
+	}{
+		{"no query", "https://api.au1.cliniko.com/v1/patients/1", nil},
+		{"filter on PHI", "https://api.au1.cliniko.com/v1/patients?q[]=last_name:=Smith&q[]=email:~jane",
+			url.Values{"q[]": {"last_name:=" + redacted, "email:~" + redacted}}},
+		{"filter on ids and timestamps", "https://api.au1.cliniko.com/v1/patients?q[]=id:=5&q[]=updated_at:>=2024-01-01T00:00:00Z&q[]=practitioner_id:=7",
+			url.Values{"q[]": {"id:=5", "updated_at:>=2024-01-01T00:00:00Z", "practitioner_id:=7"}}},
+		{"filter without operator", "https://api.au1.cliniko.com/v1/patients?q[]=Smith",
+			url.Values{"q[]": {redacted}}},
+		{"pagination and range", "https://api.au1.cliniko.com/v1/bookings?page=2&per_page=50&sort=created_at&order=desc&from=2024-01-01&to=2024-01-31",
+			url.Values{"page": {"2"}, "per_page": {"50"}, "sort": {"created_at"}, "order": {"desc"}, "from": {"2024-01-01"}, "to": {"2024-01-31"}}},
+		{"other parameters", "https://api.au1.cliniko.com/v1/patients?search=Jane+Smith&patient_id=3",
+			url.Values{"search": {redacted}, "patient_id": {"3"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			want := *u
+			want.RawQuery = tt.query.Encode()
+			if got := RedactURL(u); got != want.String() {
+				t.Errorf("RedactURL = %s, want %s", got, want.String())
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", ``, ``},
+		{"not JSON", `first_name=Jane`, redacted},
+		{"flat", `{"id":"1","first_name":"Jane","date_of_birth":"1980-01-01","created_at":"2024-01-01T00:00:00Z","archived_at":null}`,
+			`{"archived_at":null,"created_at":"2024-01-01T00:00:00Z","date_of_birth":"[REDACTED]","first_name":"[REDACTED]","id":"1"}`},
+		{"nested", `{"patients":[{"id":"2","notes":"Asthma","phone_numbers":[{"number":"0400 000 000","phone_type":"Mobile"}],"appointment_type_ids":["3","4"]}],"total_entries":1}`,
+			`{"patients":[{"appointment_type_ids":["3","4"],"id":"2","notes":"[REDACTED]","phone_numbers":[{"number":"[REDACTED]","phone_type":"[REDACTED]"}]}],"total_entries":1}`},
+		{"links", `{"patient":{"links":{"self":"https://api.au1.cliniko.com/v1/patients/2"}},"links":{"next":"https://api.au1.cliniko.com/v1/patients?page=2&q%5B%5D=last_name%3A%3DSmith"}}`,
+			`{"links":{"next":"https://api.au1.cliniko.com/v1/patients?page=2&q%5B%5D=last_name%3A%3D%5BREDACTED%5D"},"patient":{"links":{"self":"https://api.au1.cliniko.com/v1/patients/2"}}}`},
+		{"numbers and booleans", `{"total_entries":3,"did_not_arrive":true,"max_attendees":5}`,
+			`{"did_not_arrive":"[REDACTED]","max_attendees":"[REDACTED]","total_entries":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(RedactBody([]byte(tt.body))); got != tt.want {
+				t.Errorf("RedactBody = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type recordedMetrics struct {
+	counters map[string]float64
+}
+
+func (m *recordedMetrics) AddCounter(name string, labels map[string]string, value float64) {
+	m.counters[name+" "+labels["operation"]+" "+labels["status"]] += value
+}
+
+func (m *recordedMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {}
+
+func (m *recordedMetrics) SetGauge(name string, labels map[string]string, value float64) {}
+
+// TestInstrumentedDoerRetries reports a retried call once,
+// with its retries counted on the observation of the call
+func TestInstrumentedDoerRetries(t *testing.T) {
+	metrics := &recordedMetrics{counters: map[string]float64{}}
+	instrumentation := &Instrumentation{Metrics: metrics}
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = 0
+	retry := NewRetryDoer(&failingDoer{}, policy)
+	retry.OnRetry = instrumentation.OnRetry
+	doer := NewInstrumentedDoer(retry, instrumentation)
+
+	req, err := NewGetPatientGetRequest("https://api.au1.cliniko.com/v1", "1", nil)
+	if err != nil {
+		t.Fatalf("NewGetPatientGetRequest: %v", err)
+	}
+	rsp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = rsp.Body.Close()
+
+	want := map[string]float64{
+		MetricRequests + " GetPatientGet 201": 1,
+		MetricRetries + " GetPatientGet ":     1,
+	}
+	for key, value := range want {
+		if metrics.counters[key] != value {
+			t.Errorf("counter %q = %v, want %v", key, metrics.counters[key], value)
+		}
+	}
+	if len(metrics.counters) != len(want) {
+		t.Errorf("counters = %v, want %v", metrics.counters, want)
+	}
+}
@@ -0,0 +1,873 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"net/http"
+)
+
+// IterateAppointmentTypes returns an Iterator over every AppointmentType
+// returned by ListAppointmentTypesGet
+func (c *ClinikoClient) IterateAppointmentTypes(
+	ctx context.Context,
+	params *ListAppointmentTypesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[AppointmentType] {
+	return Paginate[AppointmentType](ctx, c, "appointment_types",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAppointmentTypesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePractitionersForAppointmentType returns an Iterator over every Practitioner
+// returned by ListPractitionersForAppointmentTypeGet
+func (c *ClinikoClient) IteratePractitionersForAppointmentType(
+	ctx context.Context,
+	appointmentTypeId string,
+	params *ListPractitionersForAppointmentTypeGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPractitionersForAppointmentTypeGet(ctx, appointmentTypeId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInactivePractitionersForAppointmentType returns an Iterator over every Practitioner
+// returned by ListInactivePractitionersForAppointmentTypeGet
+func (c *ClinikoClient) IterateInactivePractitionersForAppointmentType(
+	ctx context.Context,
+	appointmentTypeId string,
+	params *ListInactivePractitionersForAppointmentTypeGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInactivePractitionersForAppointmentTypeGet(ctx, appointmentTypeId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoicesForAppointment returns an Iterator over every Invoice
+// returned by ListInvoicesForAppointmentGet
+func (c *ClinikoClient) IterateInvoicesForAppointment(
+	ctx context.Context,
+	appointmentId string,
+	params *ListInvoicesForAppointmentGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesForAppointmentGet(ctx, appointmentId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAttendees returns an Iterator over every Attendee
+// returned by ListAttendeesGet
+func (c *ClinikoClient) IterateAttendees(
+	ctx context.Context,
+	params *ListAttendeesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Attendee] {
+	return Paginate[Attendee](ctx, c, "attendees",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAttendeesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoicesForAttendee returns an Iterator over every Invoice
+// returned by ListInvoicesForAttendeeGet
+func (c *ClinikoClient) IterateInvoicesForAttendee(
+	ctx context.Context,
+	attendeeId string,
+	params *ListInvoicesForAttendeeGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesForAttendeeGet(ctx, attendeeId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientFormsForAttendee returns an Iterator over every PatientForm
+// returned by ListPatientFormsForAttendeeGet
+func (c *ClinikoClient) IteratePatientFormsForAttendee(
+	ctx context.Context,
+	attendeeId string,
+	params *ListPatientFormsForAttendeeGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientForm] {
+	return Paginate[PatientForm](ctx, c, "patient_forms",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientFormsForAttendeeGet(ctx, attendeeId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAvailabilityBlocks returns an Iterator over every AvailabilityBlock
+// returned by ListAvailabilityBlocksGet
+func (c *ClinikoClient) IterateAvailabilityBlocks(
+	ctx context.Context,
+	params *ListAvailabilityBlocksGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[AvailabilityBlock] {
+	return Paginate[AvailabilityBlock](ctx, c, "availability_blocks",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAvailabilityBlocksGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateBillableItems returns an Iterator over every BillableItem
+// returned by ListBillableItemsGet
+func (c *ClinikoClient) IterateBillableItems(
+	ctx context.Context,
+	params *ListBillableItemsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[BillableItem] {
+	return Paginate[BillableItem](ctx, c, "billable_items",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListBillableItemsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateBookings returns an Iterator over every Booking
+// returned by ListBookingsGet
+func (c *ClinikoClient) IterateBookings(
+	ctx context.Context,
+	params *ListBookingsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Booking] {
+	return Paginate[Booking](ctx, c, "bookings",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListBookingsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateBusinesses returns an Iterator over every Business
+// returned by ListBusinessesGet
+func (c *ClinikoClient) IterateBusinesses(
+	ctx context.Context,
+	params *ListBusinessesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Business] {
+	return Paginate[Business](ctx, c, "businesses",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListBusinessesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateDailyAvailabilitiesForBusiness returns an Iterator over every DailyAvailability
+// returned by ListDailyAvailabilitiesForBusinessGet
+func (c *ClinikoClient) IterateDailyAvailabilitiesForBusiness(
+	ctx context.Context,
+	businessId string,
+	params *ListDailyAvailabilitiesForBusinessGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[DailyAvailability] {
+	return Paginate[DailyAvailability](ctx, c, "daily_availabilities",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListDailyAvailabilitiesForBusinessGet(ctx, businessId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePractitionersForBusiness returns an Iterator over every Practitioner
+// returned by ListPractitionersForBusinessGet
+func (c *ClinikoClient) IteratePractitionersForBusiness(
+	ctx context.Context,
+	businessId string,
+	params *ListPractitionersForBusinessGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPractitionersForBusinessGet(ctx, businessId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInactivePractitionersForBusiness returns an Iterator over every Practitioner
+// returned by ListInactivePractitionersForBusinessGet
+func (c *ClinikoClient) IterateInactivePractitionersForBusiness(
+	ctx context.Context,
+	businessId string,
+	params *ListInactivePractitionersForBusinessGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInactivePractitionersForBusinessGet(ctx, businessId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateServicesForBusiness returns an Iterator over every Service
+// returned by ListServicesForBusinessGet
+func (c *ClinikoClient) IterateServicesForBusiness(
+	ctx context.Context,
+	businessId string,
+	params *ListServicesForBusinessGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Service] {
+	return Paginate[Service](ctx, c, "services",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListServicesForBusinessGet(ctx, businessId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateCommunications returns an Iterator over every Communication
+// returned by ListCommunicationsGet
+func (c *ClinikoClient) IterateCommunications(
+	ctx context.Context,
+	params *ListCommunicationsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Communication] {
+	return Paginate[Communication](ctx, c, "communications",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListCommunicationsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateConcessionPrices returns an Iterator over every ConcessionPrice
+// returned by ListConcessionPricesGet
+func (c *ClinikoClient) IterateConcessionPrices(
+	ctx context.Context,
+	params *ListConcessionPricesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[ConcessionPrice] {
+	return Paginate[ConcessionPrice](ctx, c, "concession_prices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListConcessionPricesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateConcessionTypes returns an Iterator over every ConcessionType
+// returned by ListConcessionTypesGet
+func (c *ClinikoClient) IterateConcessionTypes(
+	ctx context.Context,
+	params *ListConcessionTypesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[ConcessionType] {
+	return Paginate[ConcessionType](ctx, c, "concession_types",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListConcessionTypesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateContacts returns an Iterator over every Contact
+// returned by ListContactsGet
+func (c *ClinikoClient) IterateContacts(
+	ctx context.Context,
+	params *ListContactsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Contact] {
+	return Paginate[Contact](ctx, c, "contacts",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListContactsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateDailyAvailabilities returns an Iterator over every DailyAvailability
+// returned by ListDailyAvailabilitiesGet
+func (c *ClinikoClient) IterateDailyAvailabilities(
+	ctx context.Context,
+	params *ListDailyAvailabilitiesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[DailyAvailability] {
+	return Paginate[DailyAvailability](ctx, c, "daily_availabilities",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListDailyAvailabilitiesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateGroupAppointments returns an Iterator over every GroupAppointment
+// returned by ListGroupAppointmentsGet
+func (c *ClinikoClient) IterateGroupAppointments(
+	ctx context.Context,
+	params *ListGroupAppointmentsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[GroupAppointment] {
+	return Paginate[GroupAppointment](ctx, c, "group_appointments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListGroupAppointmentsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAttendeesForGroupAppointment returns an Iterator over every Attendee
+// returned by ListAttendeesForGroupAppointmentGet
+func (c *ClinikoClient) IterateAttendeesForGroupAppointment(
+	ctx context.Context,
+	groupAppointmentId string,
+	params *ListAttendeesForGroupAppointmentGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Attendee] {
+	return Paginate[Attendee](ctx, c, "attendees",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAttendeesForGroupAppointmentGet(ctx, groupAppointmentId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateIndividualAppointments returns an Iterator over every IndividualAppointment
+// returned by ListIndividualAppointmentsGet
+func (c *ClinikoClient) IterateIndividualAppointments(
+	ctx context.Context,
+	params *ListIndividualAppointmentsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[IndividualAppointment] {
+	return Paginate[IndividualAppointment](ctx, c, "individual_appointments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListIndividualAppointmentsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAttendeesForIndividualAppointment returns an Iterator over every Attendee
+// returned by ListAttendeesForIndividualAppointmentGet
+func (c *ClinikoClient) IterateAttendeesForIndividualAppointment(
+	ctx context.Context,
+	individualAppointmentId string,
+	params *ListAttendeesForIndividualAppointmentGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Attendee] {
+	return Paginate[Attendee](ctx, c, "attendees",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAttendeesForIndividualAppointmentGet(ctx, individualAppointmentId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoiceItems returns an Iterator over every InvoiceItem
+// returned by ListInvoiceItemsGet
+func (c *ClinikoClient) IterateInvoiceItems(
+	ctx context.Context,
+	params *ListInvoiceItemsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[InvoiceItem] {
+	return Paginate[InvoiceItem](ctx, c, "invoice_items",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoiceItemsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoices returns an Iterator over every Invoice
+// returned by ListInvoicesGet
+func (c *ClinikoClient) IterateInvoices(
+	ctx context.Context,
+	params *ListInvoicesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoiceItemsForInvoice returns an Iterator over every InvoiceItem
+// returned by ListInvoiceItemsForInvoiceGet
+func (c *ClinikoClient) IterateInvoiceItemsForInvoice(
+	ctx context.Context,
+	invoiceId string,
+	params *ListInvoiceItemsForInvoiceGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[InvoiceItem] {
+	return Paginate[InvoiceItem](ctx, c, "invoice_items",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoiceItemsForInvoiceGet(ctx, invoiceId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateMedicalAlerts returns an Iterator over every MedicalAlert
+// returned by ListMedicalAlertsGet
+func (c *ClinikoClient) IterateMedicalAlerts(
+	ctx context.Context,
+	params *ListMedicalAlertsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[MedicalAlert] {
+	return Paginate[MedicalAlert](ctx, c, "medical_alerts",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListMedicalAlertsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientAttachments returns an Iterator over every PatientAttachment
+// returned by ListPatientAttachmentsGet
+func (c *ClinikoClient) IteratePatientAttachments(
+	ctx context.Context,
+	params *ListPatientAttachmentsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientAttachment] {
+	return Paginate[PatientAttachment](ctx, c, "patient_attachments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientAttachmentsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientCases returns an Iterator over every PatientCase
+// returned by ListPatientCasesGet
+func (c *ClinikoClient) IteratePatientCases(
+	ctx context.Context,
+	params *ListPatientCasesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientCase] {
+	return Paginate[PatientCase](ctx, c, "patient_cases",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientCasesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateActivePatientCases returns an Iterator over every PatientCase
+// returned by ListActivePatientCasesGet
+func (c *ClinikoClient) IterateActivePatientCases(
+	ctx context.Context,
+	params *ListActivePatientCasesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientCase] {
+	return Paginate[PatientCase](ctx, c, "patient_cases",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListActivePatientCasesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAttendeesForPatientCase returns an Iterator over every Attendee
+// returned by ListAttendeesForPatientCaseGet
+func (c *ClinikoClient) IterateAttendeesForPatientCase(
+	ctx context.Context,
+	patientCaseId string,
+	params *ListAttendeesForPatientCaseGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Attendee] {
+	return Paginate[Attendee](ctx, c, "attendees",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAttendeesForPatientCaseGet(ctx, patientCaseId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateBookingsForPatientCase returns an Iterator over every Booking
+// returned by ListBookingsForPatientCaseGet
+func (c *ClinikoClient) IterateBookingsForPatientCase(
+	ctx context.Context,
+	patientCaseId string,
+	params *ListBookingsForPatientCaseGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Booking] {
+	return Paginate[Booking](ctx, c, "bookings",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListBookingsForPatientCaseGet(ctx, patientCaseId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoicesForPatientCase returns an Iterator over every Invoice
+// returned by ListInvoicesForPatientCaseGet
+func (c *ClinikoClient) IterateInvoicesForPatientCase(
+	ctx context.Context,
+	patientCaseId string,
+	params *ListInvoicesForPatientCaseGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesForPatientCaseGet(ctx, patientCaseId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientAttachmentsForPatientCase returns an Iterator over every PatientAttachment
+// returned by ListPatientAttachmentsForPatientCaseGet
+func (c *ClinikoClient) IteratePatientAttachmentsForPatientCase(
+	ctx context.Context,
+	patientCaseId string,
+	params *ListPatientAttachmentsForPatientCaseGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientAttachment] {
+	return Paginate[PatientAttachment](ctx, c, "patient_attachments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientAttachmentsForPatientCaseGet(ctx, patientCaseId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientFormTemplates returns an Iterator over every PatientFormTemplate
+// returned by ListPatientFormTemplatesGet
+func (c *ClinikoClient) IteratePatientFormTemplates(
+	ctx context.Context,
+	params *ListPatientFormTemplatesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientFormTemplate] {
+	return Paginate[PatientFormTemplate](ctx, c, "patient_form_templates",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientFormTemplatesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientForms returns an Iterator over every PatientForm
+// returned by ListPatientFormsGet
+func (c *ClinikoClient) IteratePatientForms(
+	ctx context.Context,
+	params *ListPatientFormsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientForm] {
+	return Paginate[PatientForm](ctx, c, "patient_forms",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientFormsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatients returns an Iterator over every Patient
+// returned by ListPatientsGet
+func (c *ClinikoClient) IteratePatients(
+	ctx context.Context,
+	params *ListPatientsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Patient] {
+	return Paginate[Patient](ctx, c, "patients",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoicesForPatient returns an Iterator over every Invoice
+// returned by ListInvoicesForPatientGet
+func (c *ClinikoClient) IterateInvoicesForPatient(
+	ctx context.Context,
+	patientId string,
+	params *ListInvoicesForPatientGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesForPatientGet(ctx, patientId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateMedicalAlertsForPatient returns an Iterator over every MedicalAlert
+// returned by ListMedicalAlertsForPatientGet
+func (c *ClinikoClient) IterateMedicalAlertsForPatient(
+	ctx context.Context,
+	patientId string,
+	params *ListMedicalAlertsForPatientGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[MedicalAlert] {
+	return Paginate[MedicalAlert](ctx, c, "medical_alerts",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListMedicalAlertsForPatientGet(ctx, patientId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePatientAttachmentsForPatient returns an Iterator over every PatientAttachment
+// returned by ListPatientAttachmentsForPatientGet
+func (c *ClinikoClient) IteratePatientAttachmentsForPatient(
+	ctx context.Context,
+	patientId string,
+	params *ListPatientAttachmentsForPatientGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PatientAttachment] {
+	return Paginate[PatientAttachment](ctx, c, "patient_attachments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPatientAttachmentsForPatientGet(ctx, patientId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateTreatmentNotesForPatient returns an Iterator over every TreatmentNote
+// returned by ListTreatmentNotesForPatientGet
+func (c *ClinikoClient) IterateTreatmentNotesForPatient(
+	ctx context.Context,
+	patientId string,
+	params *ListTreatmentNotesForPatientGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[TreatmentNote] {
+	return Paginate[TreatmentNote](ctx, c, "treatment_notes",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListTreatmentNotesForPatientGet(ctx, patientId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePractitionerReferenceNumbers returns an Iterator over every PractitionerReferenceNumber
+// returned by ListPractitionerReferenceNumbersGet
+func (c *ClinikoClient) IteratePractitionerReferenceNumbers(
+	ctx context.Context,
+	params *ListPractitionerReferenceNumbersGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PractitionerReferenceNumber] {
+	return Paginate[PractitionerReferenceNumber](ctx, c, "practitioner_reference_numbers",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPractitionerReferenceNumbersGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePractitioners returns an Iterator over every Practitioner
+// returned by ListPractitionersGet
+func (c *ClinikoClient) IteratePractitioners(
+	ctx context.Context,
+	params *ListPractitionersGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPractitionersGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInactivePractitioners returns an Iterator over every Practitioner
+// returned by ListInactivePractitionersGet
+func (c *ClinikoClient) IterateInactivePractitioners(
+	ctx context.Context,
+	params *ListInactivePractitionersGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Practitioner] {
+	return Paginate[Practitioner](ctx, c, "practitioners",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInactivePractitionersGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateAppointmentTypesForPractitioner returns an Iterator over every AppointmentType
+// returned by ListAppointmentTypesForPractitionerGet
+func (c *ClinikoClient) IterateAppointmentTypesForPractitioner(
+	ctx context.Context,
+	practitionerId string,
+	params *ListAppointmentTypesForPractitionerGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[AppointmentType] {
+	return Paginate[AppointmentType](ctx, c, "appointment_types",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListAppointmentTypesForPractitionerGet(ctx, practitionerId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateDailyAvailabilitiesForPractitioner returns an Iterator over every DailyAvailability
+// returned by ListDailyAvailabilitiesForPractitionerGet
+func (c *ClinikoClient) IterateDailyAvailabilitiesForPractitioner(
+	ctx context.Context,
+	practitionerId string,
+	params *ListDailyAvailabilitiesForPractitionerGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[DailyAvailability] {
+	return Paginate[DailyAvailability](ctx, c, "daily_availabilities",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListDailyAvailabilitiesForPractitionerGet(ctx, practitionerId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateInvoicesForPractitioner returns an Iterator over every Invoice
+// returned by ListInvoicesForPractitionerGet
+func (c *ClinikoClient) IterateInvoicesForPractitioner(
+	ctx context.Context,
+	practitionerId string,
+	params *ListInvoicesForPractitionerGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Invoice] {
+	return Paginate[Invoice](ctx, c, "invoices",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListInvoicesForPractitionerGet(ctx, practitionerId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IteratePractitionerReferenceNumbersForPractitioner returns an Iterator over every PractitionerReferenceNumber
+// returned by ListPractitionerReferenceNumbersForPractitionerGet
+func (c *ClinikoClient) IteratePractitionerReferenceNumbersForPractitioner(
+	ctx context.Context,
+	practitionerId string,
+	params *ListPractitionerReferenceNumbersForPractitionerGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[PractitionerReferenceNumber] {
+	return Paginate[PractitionerReferenceNumber](ctx, c, "practitioner_reference_numbers",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListPractitionerReferenceNumbersForPractitionerGet(ctx, practitionerId, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateProductSuppliers returns an Iterator over every ProductSupplier
+// returned by ListProductSuppliersGet
+func (c *ClinikoClient) IterateProductSuppliers(
+	ctx context.Context,
+	params *ListProductSuppliersGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[ProductSupplier] {
+	return Paginate[ProductSupplier](ctx, c, "product_suppliers",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListProductSuppliersGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateProducts returns an Iterator over every Product
+// returned by ListProductsGet
+func (c *ClinikoClient) IterateProducts(
+	ctx context.Context,
+	params *ListProductsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Product] {
+	return Paginate[Product](ctx, c, "products",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListProductsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateReferralSourceTypes returns an Iterator over every ReferralSourceType
+// returned by ListReferralSourceTypesGet
+func (c *ClinikoClient) IterateReferralSourceTypes(
+	ctx context.Context,
+	params *ListReferralSourceTypesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[ReferralSourceType] {
+	return Paginate[ReferralSourceType](ctx, c, "referral_source_types",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListReferralSourceTypesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateReferralSources returns an Iterator over every ReferralSource
+// returned by ListReferralSourcesGet
+func (c *ClinikoClient) IterateReferralSources(
+	ctx context.Context,
+	params *ListReferralSourcesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[ReferralSource] {
+	return Paginate[ReferralSource](ctx, c, "referral_sources",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListReferralSourcesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateServices returns an Iterator over every Service
+// returned by ListServicesGet
+func (c *ClinikoClient) IterateServices(
+	ctx context.Context,
+	params *ListServicesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Service] {
+	return Paginate[Service](ctx, c, "services",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListServicesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateStockAdjustments returns an Iterator over every StockAdjustment
+// returned by ListStockAdjustmentsGet
+func (c *ClinikoClient) IterateStockAdjustments(
+	ctx context.Context,
+	params *ListStockAdjustmentsGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[StockAdjustment] {
+	return Paginate[StockAdjustment](ctx, c, "stock_adjustments",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListStockAdjustmentsGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateTaxes returns an Iterator over every Tax
+// returned by ListTaxesGet
+func (c *ClinikoClient) IterateTaxes(
+	ctx context.Context,
+	params *ListTaxesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[Tax] {
+	return Paginate[Tax](ctx, c, "taxes",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListTaxesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateTreatmentNoteTemplates returns an Iterator over every TreatmentNoteTemplate
+// returned by ListTreatmentNoteTemplatesGet
+func (c *ClinikoClient) IterateTreatmentNoteTemplates(
+	ctx context.Context,
+	params *ListTreatmentNoteTemplatesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[TreatmentNoteTemplate] {
+	return Paginate[TreatmentNoteTemplate](ctx, c, "treatment_note_templates",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListTreatmentNoteTemplatesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateTreatmentNotes returns an Iterator over every TreatmentNote
+// returned by ListTreatmentNotesGet
+func (c *ClinikoClient) IterateTreatmentNotes(
+	ctx context.Context,
+	params *ListTreatmentNotesGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[TreatmentNote] {
+	return Paginate[TreatmentNote](ctx, c, "treatment_notes",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListTreatmentNotesGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateUnavailableBlocks returns an Iterator over every UnavailableBlock
+// returned by ListUnavailableBlocksGet
+func (c *ClinikoClient) IterateUnavailableBlocks(
+	ctx context.Context,
+	params *ListUnavailableBlocksGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[UnavailableBlock] {
+	return Paginate[UnavailableBlock](ctx, c, "unavailable_blocks",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListUnavailableBlocksGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
+
+// IterateUsers returns an Iterator over every User
+// returned by ListUsersGet
+func (c *ClinikoClient) IterateUsers(
+	ctx context.Context,
+	params *ListUsersGetParams,
+	reqEditors ...RequestEditorFn,
+) *Iterator[User] {
+	return Paginate[User](ctx, c, "users",
+		func(ctx context.Context) (*http.Response, error) {
+			return c.Client.ListUsersGet(ctx, params, reqEditors...)
+		},
+		reqEditors...)
+}
@@ -0,0 +1,418 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of decimal places Money stores,
+// enough for unit prices given with more than two decimals
+const moneyScale = 4
+
+// moneyUnit is the number of Money units in one major unit
+const moneyUnit = 10000
+
+// ErrCurrencyMismatch is returned when amounts
+// of different currencies are combined
+var ErrCurrencyMismatch = errors.New("cliniko: currency mismatch")
+
+// Currency is the currency of an account
+type Currency struct {
+	// Code is the ISO 4217 code, e.g. "AUD"
+	Code string
+	// Symbol is the symbol shown by Cliniko, e.g. "$"
+	Symbol string
+	// Digits is the number of decimal places of the minor unit
+	Digits int
+}
+
+// digits returns the decimal places of the minor unit,
+// amounts without currency are treated as having two
+func (c Currency) digits() int {
+	if c == (Currency{}) {
+		return 2
+	}
+	return c.Digits
+}
+
+// currencies maps ISO 3166-1 country codes to
+// the currency used by accounts in that country
+var currencies = map[string]Currency{
+	"AU": {"AUD", "$", 2},
+	"NZ": {"NZD", "$", 2},
+	"GB": {"GBP", "£", 2},
+	// UK is not assigned in ISO 3166-1 but reserved for the
+	// United Kingdom at its request, accounts may carry it
+	"UK": {"GBP", "£", 2},
+	"IE": {"EUR", "€", 2},
+	"DE": {"EUR", "€", 2},
+	"FR": {"EUR", "€", 2},
+	"NL": {"EUR", "€", 2},
+	"BE": {"EUR", "€", 2},
+	"ES": {"EUR", "€", 2},
+	"IT": {"EUR", "€", 2},
+	"AT": {"EUR", "€", 2},
+	"PT": {"EUR", "€", 2},
+	"FI": {"EUR", "€", 2},
+	"US": {"USD", "$", 2},
+	"CA": {"CAD", "$", 2},
+	"ZA": {"ZAR", "R", 2},
+	"SG": {"SGD", "$", 2},
+	"HK": {"HKD", "$", 2},
+	"MY": {"MYR", "RM", 2},
+	"AE": {"AED", "AED", 2},
+	"CH": {"CHF", "CHF", 2},
+	"SE": {"SEK", "kr", 2},
+	"NO": {"NOK", "kr", 2},
+	"DK": {"DKK", "kr", 2},
+	"JP": {"JPY", "¥", 0},
+	"KR": {"KRW", "₩", 0},
+	"BH": {"BHD", "BD", 3},
+	"KW": {"KWD", "KD", 3},
+}
+
+// CurrencyForCountry returns the currency of the given
+// ISO 3166-1 country code, e.g. "AU"
+func CurrencyForCountry(countryCode string) (Currency, bool) {
+	currency, ok := currencies[strings.ToUpper(countryCode)]
+	return currency, ok
+}
+
+// AccountCurrency returns the currency of the account based on
+// Settings.Account, the currency symbol configured in Cliniko
+// takes precedence over the one of the country. Accounts in
+// unknown countries get a currency without code and 2 digits.
+func (c *ClinikoClient) AccountCurrency(
+	ctx context.Context,
+	reqEditors ...RequestEditorFn,
+) (
+	Currency, error,
+) {
+	rsp, err := c.GetSettingsGetWithResponse(ctx, reqEditors...)
+	if err != nil {
+		return Currency{}, err
+	}
+
+	if rsp.JSON200 == nil {
+		return Currency{},
+			unsuccessfulResponse(
+				"get settings request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+
+	currency := Currency{Digits: 2}
+	account := rsp.JSON200.Account
+	if account == nil {
+		return currency, nil
+	}
+
+	if account.CountryCode != nil {
+		if known, ok := CurrencyForCountry(*account.CountryCode); ok {
+			currency = known
+		}
+	}
+	if account.CurrencySymbol != nil && *account.CurrencySymbol != "" {
+		currency.Symbol = *account.CurrencySymbol
+	}
+	return currency, nil
+}
+
+// Money is a fixed-point amount of a currency with four decimal
+// places. Amounts are sent by the API as decimal strings, e.g.
+// "120.50", Money marshals to and from that format. Amounts
+// range from -922337203685477.5807 to 922337203685477.5807.
+type Money struct {
+	units    int64
+	currency Currency
+}
+
+// NewMoney returns the amount given in minor units
+// of the currency, e.g. cents for AUD
+func NewMoney(minorUnits int64, currency Currency) Money {
+	return Money{
+		units:    minorUnits * pow10(moneyScale-currency.digits()),
+		currency: currency,
+	}
+}
+
+// ParseMoney parses a decimal amount such as "-12.5"
+// or "1200.0000" as returned by the API
+func ParseMoney(value string, currency Currency) (Money, error) {
+	units, err := parseMoneyUnits(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, currency: currency}, nil
+}
+
+func parseMoneyUnits(value string) (int64, error) {
+	text := strings.TrimSpace(value)
+	negative := strings.HasPrefix(text, "-")
+	if negative || strings.HasPrefix(text, "+") {
+		text = text[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" ||
+		strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("cliniko: invalid amount %q", value)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > moneyScale {
+		return 0, fmt.Errorf("cliniko: amount %q has more than %d decimals", value, moneyScale)
+	}
+	fraction += strings.Repeat("0", moneyScale-len(fraction))
+
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + fraction
+	if negative {
+		digits = "-" + digits
+	}
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cliniko: invalid amount %q: %w", value, err)
+	}
+	// the most negative int64 has no positive counterpart
+	if units == math.MinInt64 {
+		return 0, fmt.Errorf("cliniko: amount %q is out of range", value)
+	}
+	return units, nil
+}
+
+// Currency returns the currency of the amount
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// WithCurrency returns the amount in the given currency,
+// the amount itself is not converted
+func (m Money) WithCurrency(currency Currency) Money {
+	m.currency = currency
+	return m
+}
+
+// MinorUnits returns the amount in minor units of its
+// currency, rounded half away from zero
+func (m Money) MinorUnits() int64 {
+	return m.Round().units / pow10(moneyScale-m.currency.digits())
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: m.units + other.units, currency: currency}, nil
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	m.units = -m.units
+	return m
+}
+
+// Mul returns the amount multiplied by quantity
+func (m Money) Mul(quantity int64) Money {
+	m.units *= quantity
+	return m
+}
+
+// MulRate returns the amount multiplied by the decimal rate
+// rate / 10^scale, e.g. 1 and 1 for a tax of 10% or 1575 and 4
+// for 15.75%, rounded half away from zero to four decimals. It
+// returns an error if the result is out of range.
+func (m Money) MulRate(rate int64, scale int) (Money, error) {
+	if scale < 0 {
+		return Money{}, fmt.Errorf("cliniko: negative rate scale %d", scale)
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.units), big.NewInt(rate))
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+
+	// round half away from zero
+	if remainder.Lsh(remainder.Abs(remainder), 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+
+	if !quotient.IsInt64() || quotient.Int64() == math.MinInt64 {
+		return Money{}, fmt.Errorf("cliniko: %s times %d/10^%d is out of range", m, rate, scale)
+	}
+	m.units = quotient.Int64()
+	return m, nil
+}
+
+// Round returns the amount rounded half away from
+// zero to the minor unit of its currency
+func (m Money) Round() Money {
+	step := pow10(moneyScale - m.currency.digits())
+	remainder := m.units % step
+	m.units -= remainder
+	if remainder*2 >= step {
+		m.units += step
+	} else if remainder*2 <= -step {
+		m.units -= step
+	}
+	return m
+}
+
+// Cmp compares the amounts and returns -1, 0 or +1. Amounts
+// of different currencies can not be compared and return
+// ErrCurrencyMismatch, amounts without currency compare with
+// any currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.commonCurrency(other); err != nil {
+		return 0, err
+	}
+	return compareUnits(m.units, other.units), nil
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount
+func (m Money) Sign() int {
+	return compareUnits(m.units, 0)
+}
+
+func compareUnits(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// String returns the amount as decimal string as sent to the
+// API, with at least as many decimals as the currency has
+func (m Money) String() string {
+	// the magnitude is unsigned so that the most negative
+	// int64, e.g. after an overflow, is printed correctly
+	units := uint64(m.units)
+	sign := ""
+	if m.units < 0 {
+		sign, units = "-", -units
+	}
+
+	whole := units / moneyUnit
+	fraction := fmt.Sprintf("%0*d", moneyScale, units%moneyUnit)
+	for len(fraction) > m.currency.digits() && strings.HasSuffix(fraction, "0") {
+		fraction = strings.TrimSuffix(fraction, "0")
+	}
+
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	return fmt.Sprintf("%s%d.%s", sign, whole, fraction)
+}
+
+// Format returns the rounded amount with the currency
+// symbol, e.g. "$120.50" or "-$3.00"
+func (m Money) Format() string {
+	amount := m.Round().String()
+	if negative := strings.TrimPrefix(amount, "-"); negative != amount {
+		return "-" + m.currency.Symbol + negative
+	}
+	return m.currency.Symbol + amount
+}
+
+// MarshalJSON encodes the amount as a decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes an amount given as decimal string
+// or number, the currency is left unchanged. Like the standard
+// library, null leaves the amount unchanged.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("cliniko: invalid amount %s", data)
+		}
+		value = number.String()
+	}
+
+	units, err := parseMoneyUnits(value)
+	if err != nil {
+		return err
+	}
+	m.units = units
+	return nil
+}
+
+// commonCurrency returns the currency of an operation, amounts
+// without currency take the currency of the other amount
+func (m Money) commonCurrency(other Money) (Currency, error) {
+	switch {
+	case m.currency == Currency{}:
+		return other.currency, nil
+	case other.currency == Currency{}:
+		return m.currency, nil
+	case m.currency != other.currency:
+		return Currency{}, fmt.Errorf(
+			"%w: %s and %s",
+			ErrCurrencyMismatch,
+			m.currency.Code,
+			other.currency.Code,
+		)
+	}
+	return m.currency, nil
+}
+
+// moneyField converts an amount field of a generated
+// type, nil fields are returned as nil
+func moneyField(value *string, currency Currency) (*Money, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	m, err := ParseMoney(*value, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// moneyString converts an amount for a field of a generated type
+func moneyString(m Money) *string {
+	value := m.String()
+	return &value
+}
+
+func pow10(n int) int64 {
+	result := int64(1)
+	for ; n > 0; n-- {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+// DepositPriceMoney returns DepositPrice as Money in the given currency,
+// or nil if it is not set
+func (a AppointmentType) DepositPriceMoney(currency Currency) (*Money, error) {
+	return moneyField(a.DepositPrice, currency)
+}
+
+// PriceMoney returns Price as Money in the given currency,
+// or nil if it is not set
+func (b BillableItem) PriceMoney(currency Currency) (*Money, error) {
+	return moneyField(b.Price, currency)
+}
+
+// PriceMoney returns Price as Money in the given currency,
+// or nil if it is not set
+func (c ConcessionPrice) PriceMoney(currency Currency) (*Money, error) {
+	return moneyField(c.Price, currency)
+}
+
+// DiscountedAmountMoney returns DiscountedAmount as Money in the given currency,
+// or nil if it is not set
+func (i Invoice) DiscountedAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(i.DiscountedAmount, currency)
+}
+
+// NetAmountMoney returns NetAmount as Money in the given currency,
+// or nil if it is not set
+func (i Invoice) NetAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(i.NetAmount, currency)
+}
+
+// TaxAmountMoney returns TaxAmount as Money in the given currency,
+// or nil if it is not set
+func (i Invoice) TaxAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(i.TaxAmount, currency)
+}
+
+// TotalAmountMoney returns TotalAmount as Money in the given currency,
+// or nil if it is not set
+func (i Invoice) TotalAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(i.TotalAmount, currency)
+}
+
+// DiscountedAmountMoney returns DiscountedAmount as Money in the given currency,
+// or nil if it is not set
+func (i InvoiceItem) DiscountedAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(i.DiscountedAmount, currency)
+}
+
+// NetPriceMoney returns NetPrice as Money in the given currency,
+// or nil if it is not set
+func (i InvoiceItem) NetPriceMoney(currency Currency) (*Money, error) {
+	return moneyField(i.NetPrice, currency)
+}
+
+// UnitPriceMoney returns UnitPrice as Money in the given currency,
+// or nil if it is not set
+func (i InvoiceItem) UnitPriceMoney(currency Currency) (*Money, error) {
+	return moneyField(i.UnitPrice, currency)
+}
+
+// MaxInvoiceableAmountMoney returns MaxInvoiceableAmount as Money in the given currency,
+// or nil if it is not set
+func (p PatientCase) MaxInvoiceableAmountMoney(currency Currency) (*Money, error) {
+	return moneyField(p.MaxInvoiceableAmount, currency)
+}
+
+// CostPriceMoney returns CostPrice as Money in the given currency,
+// or nil if it is not set
+func (p Product) CostPriceMoney(currency Currency) (*Money, error) {
+	return moneyField(p.CostPrice, currency)
+}
+
+// PriceExTaxMoney returns PriceExTax as Money in the given currency,
+// or nil if it is not set
+func (p Product) PriceExTaxMoney(currency Currency) (*Money, error) {
+	return moneyField(p.PriceExTax, currency)
+}
+
+// PriceIncludingTaxMoney returns PriceIncludingTax as Money in the given currency,
+// or nil if it is not set
+func (p Product) PriceIncludingTaxMoney(currency Currency) (*Money, error) {
+	return moneyField(p.PriceIncludingTax, currency)
+}
+
+// SetDepositPriceMoney sets DepositPrice to the given amount
+func (a *AppointmentTypeRequest) SetDepositPriceMoney(amount Money) {
+	a.DepositPrice = moneyString(amount)
+}
+
+// SetPriceMoney sets Price to the given amount
+func (b *BillableItemRequest) SetPriceMoney(amount Money) {
+	b.Price = moneyString(amount)
+}
+
+// SetMaxInvoiceableAmountMoney sets MaxInvoiceableAmount to the given amount
+func (p *PatientCaseRequest) SetMaxInvoiceableAmountMoney(amount Money) {
+	p.MaxInvoiceableAmount = moneyString(amount)
+}
+
+// SetCostPriceMoney sets CostPrice to the given amount
+func (p *ProductRequest) SetCostPriceMoney(amount Money) {
+	p.CostPrice = moneyString(amount)
+}
+
+// SetCostPriceMoney sets CostPrice to the given amount
+func (p *ProductUpdateRequest) SetCostPriceMoney(amount Money) {
+	p.CostPrice = moneyString(amount)
+}
+
+// SetDepositPriceMoney sets DepositPrice to the given amount
+func (c *CreateAppointmentTypePostJSONRequestBody) SetDepositPriceMoney(amount Money) {
+	c.DepositPrice = moneyString(amount)
+}
+
+// SetDepositPriceMoney sets DepositPrice to the given amount
+func (u *UpdateAppointmentTypePatchJSONRequestBody) SetDepositPriceMoney(amount Money) {
+	u.DepositPrice = moneyString(amount)
+}
+
+// SetPriceMoney sets Price to the given amount
+func (c *CreateBillableItemPostJSONRequestBody) SetPriceMoney(amount Money) {
+	c.Price = moneyString(amount)
+}
+
+// SetPriceMoney sets Price to the given amount
+func (u *UpdateBillableItemPatchJSONRequestBody) SetPriceMoney(amount Money) {
+	u.Price = moneyString(amount)
+}
+
+// SetMaxInvoiceableAmountMoney sets MaxInvoiceableAmount to the given amount
+func (c *CreatePatientCasePostJSONRequestBody) SetMaxInvoiceableAmountMoney(amount Money) {
+	c.MaxInvoiceableAmount = moneyString(amount)
+}
+
+// SetMaxInvoiceableAmountMoney sets MaxInvoiceableAmount to the given amount
+func (u *UpdatePatientCasePatchJSONRequestBody) SetMaxInvoiceableAmountMoney(amount Money) {
+	u.MaxInvoiceableAmount = moneyString(amount)
+}
+
+// SetCostPriceMoney sets CostPrice to the given amount
+func (c *CreateProductPostJSONRequestBody) SetCostPriceMoney(amount Money) {
+	c.CostPrice = moneyString(amount)
+}
+
+// SetCostPriceMoney sets CostPrice to the given amount
+func (u *UpdateProductPatchJSONRequestBody) SetCostPriceMoney(amount Money) {
+	u.CostPrice = moneyString(amount)
+}
@@ -0,0 +1,205 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParseMoneyUnits(t *testing.T) {
+	tests := []struct {
+		value string
+		units int64
+		valid bool
+	}{
+		{"0", 0, true},
+		{"120.50", 1205000, true},
+		{"120.5", 1205000, true},
+		{"0.0001", 1, true},
+		{".5", 5000, true},
+		{"5.", 50000, true},
+		{" 7.25 ", 72500, true},
+		{"-3", -30000, true},
+		{"-0.0001", -1, true},
+		{"-.5", -5000, true},
+		{"+12", 120000, true},
+		{"-0", 0, true},
+		{"1.23450000", 12345, true},
+		{"922337203685477.5807", 9223372036854775807, true},
+
+		// more decimals than the scale
+		{"0.00001", 0, false},
+		{"1.23456", 0, false},
+		// beyond int64
+		{"922337203685477.5808", 0, false},
+		{"-922337203685477.5808", 0, false},
+		{"99999999999999999999", 0, false},
+		// malformed
+		{"", 0, false},
+		{".", 0, false},
+		{"-", 0, false},
+		{"--5", 0, false},
+		{"-+5", 0, false},
+		{"+-5", 0, false},
+		{"1,000.00", 0, false},
+		{"1.2.3", 0, false},
+		{"$5", 0, false},
+		{"1e3", 0, false},
+		{"null", 0, false},
+	}
+
+	for _, tt := range tests {
+		units, err := parseMoneyUnits(tt.value)
+		if tt.valid && (err != nil || units != tt.units) {
+			t.Errorf("parseMoneyUnits(%q) = %d, %v, want %d", tt.value, units, err, tt.units)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("parseMoneyUnits(%q) = %d, want an error", tt.value, units)
+		}
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data  string
+		units int64
+		valid bool
+	}{
+		{`"120.50"`, 1205000, true},
+		{`120.5`, 1205000, true},
+		{`-3`, -30000, true},
+		{`0.0001`, 1, true},
+		{`null`, 42, true},
+		{`"null"`, 0, false},
+		{`0.00001`, 0, false},
+		{`true`, 0, false},
+		{`{}`, 0, false},
+	}
+
+	for _, tt := range tests {
+		m := Money{units: 42}
+		err := json.Unmarshal([]byte(tt.data), &m)
+		if tt.valid && (err != nil || m.units != tt.units) {
+			t.Errorf("unmarshal %s = %d, %v, want %d", tt.data, m.units, err, tt.units)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("unmarshal %s = %d, want an error", tt.data, m.units)
+		}
+	}
+
+	var fields struct {
+		Total    Money  `json:"total"`
+		Discount *Money `json:"discount"`
+	}
+	if err := json.Unmarshal([]byte(`{"total": null, "discount": null}`), &fields); err != nil {
+		t.Errorf("unmarshal null fields: %v", err)
+	}
+	if fields.Discount != nil {
+		t.Errorf("null pointer field = %v, want nil", fields.Discount)
+	}
+}
+
+func TestMoneyMulRate(t *testing.T) {
+	aud, _ := CurrencyForCountry("AU")
+
+	tests := []struct {
+		amount string
+		rate   int64
+		scale  int
+		want   string
+		valid  bool
+	}{
+		{"100", 1, 1, "10.00", true},
+		{"19.99", 1, 1, "1.999", true},
+		{"0.0015", 5, 1, "0.0008", true},
+		{"-0.0015", 5, 1, "-0.0008", true},
+		{"0.0014", 5, 1, "0.0007", true},
+		{"120.50", 1575, 4, "18.9788", true},
+		{"120.50", 3, 0, "361.50", true},
+		{"-120.50", 1, 2, "-1.205", true},
+		{"1", 3, 30, "0.00", true},
+		{"922337203685477.5807", 2, 0, "", false},
+		{"1", 1, -1, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+"*"+strconv.FormatInt(tt.rate, 10), func(t *testing.T) {
+			m, err := ParseMoney(tt.amount, aud)
+			if err != nil {
+				t.Fatalf("ParseMoney: %v", err)
+			}
+
+			got, err := m.MulRate(tt.rate, tt.scale)
+			if tt.valid && (err != nil || got.String() != tt.want) {
+				t.Errorf("MulRate(%d, %d) = %s, %v, want %s", tt.rate, tt.scale, got, err, tt.want)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("MulRate(%d, %d) = %s, want an error", tt.rate, tt.scale, got)
+			}
+		})
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	aud, _ := CurrencyForCountry("AU")
+	gbp, _ := CurrencyForCountry("GB")
+
+	tests := []struct {
+		name  string
+		a, b  Money
+		want  int
+		valid bool
+	}{
+		{"less", NewMoney(100, aud), NewMoney(200, aud), -1, true},
+		{"equal", NewMoney(100, aud), NewMoney(100, aud), 0, true},
+		{"greater", NewMoney(300, aud), NewMoney(200, aud), 1, true},
+		{"without currency", NewMoney(100, aud), NewMoney(100, Currency{}), 0, true},
+		{"different currencies", NewMoney(100, aud), NewMoney(100, gbp), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Cmp(tt.b)
+			if tt.valid && (err != nil || got != tt.want) {
+				t.Errorf("Cmp = %d, %v, want %d", got, err, tt.want)
+			}
+			if !tt.valid && !errors.Is(err, ErrCurrencyMismatch) {
+				t.Errorf("Cmp = %d, %v, want ErrCurrencyMismatch", got, err)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	aud, _ := CurrencyForCountry("AU")
+	jpy, _ := CurrencyForCountry("JP")
+
+	tests := []struct {
+		money  Money
+		string string
+		format string
+	}{
+		{NewMoney(12050, aud), "120.50", "$120.50"},
+		{NewMoney(-300, aud), "-3.00", "-$3.00"},
+		{Money{units: 12345, currency: aud}, "1.2345", "$1.23"},
+		{NewMoney(500, jpy), "500", "¥500"},
+		{Money{units: math.MaxInt64, currency: aud}, "922337203685477.5807", "$922337203685477.58"},
+		{Money{units: math.MinInt64, currency: aud}, "-922337203685477.5808", "-$922337203685477.58"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.string, func(t *testing.T) {
+			if got := tt.money.String(); got != tt.string {
+				t.Errorf("String = %s, want %s", got, tt.string)
+			}
+			if got := tt.money.Format(); got != tt.format {
+				t.Errorf("Format = %s, want %s", got, tt.format)
+			}
+		})
+	}
+}
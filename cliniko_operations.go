@@ -0,0 +1,198 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+// operationRoutes maps the method and path of every endpoint,
+// with "*" for path parameters, to the name of its operation
+var operationRoutes = []operationRoute{
+	{"GET", "/appointment_types", "ListAppointmentTypesGet"},
+	{"POST", "/appointment_types", "CreateAppointmentTypePost"},
+	{"DELETE", "/appointment_types/*", "DeleteAppointmentTypeDelete"},
+	{"GET", "/appointment_types/*", "GetAppointmentTypeGet"},
+	{"PATCH", "/appointment_types/*", "UpdateAppointmentTypePatch"},
+	{"POST", "/appointment_types/*/archive", "ArchiveAppointmentTypePost"},
+	{"GET", "/appointment_types/*/practitioners", "ListPractitionersForAppointmentTypeGet"},
+	{"GET", "/appointment_types/*/practitioners/inactive", "ListInactivePractitionersForAppointmentTypeGet"},
+	{"GET", "/appointments/*/invoices", "ListInvoicesForAppointmentGet"},
+	{"GET", "/attendees", "ListAttendeesGet"},
+	{"POST", "/attendees", "CreateAttendeePost"},
+	{"DELETE", "/attendees/*", "DeleteAttendeeDelete"},
+	{"GET", "/attendees/*", "GetAttendeeGet"},
+	{"PATCH", "/attendees/*", "UpdateAttendeePatch"},
+	{"POST", "/attendees/*/archive", "ArchiveAttendeePost"},
+	{"PATCH", "/attendees/*/cancel", "CancelAttendeePatch"},
+	{"GET", "/attendees/*/invoices", "ListInvoicesForAttendeeGet"},
+	{"GET", "/attendees/*/patient_forms", "ListPatientFormsForAttendeeGet"},
+	{"GET", "/availability_blocks", "ListAvailabilityBlocksGet"},
+	{"POST", "/availability_blocks", "CreateAvailabilityBlockPost"},
+	{"GET", "/availability_blocks/*", "GetAvailabilityBlockGet"},
+	{"GET", "/billable_items", "ListBillableItemsGet"},
+	{"POST", "/billable_items", "CreateBillableItemPost"},
+	{"DELETE", "/billable_items/*", "DeleteBillableItemDelete"},
+	{"GET", "/billable_items/*", "GetBillableItemGet"},
+	{"PATCH", "/billable_items/*", "UpdateBillableItemPatch"},
+	{"POST", "/billable_items/*/archive", "ArchiveBillableItemPost"},
+	{"GET", "/bookings", "ListBookingsGet"},
+	{"GET", "/bookings/*", "GetBookingGet"},
+	{"GET", "/businesses", "ListBusinessesGet"},
+	{"POST", "/businesses", "CreateBusinessPost"},
+	{"DELETE", "/businesses/*", "DeleteBusinessDelete"},
+	{"GET", "/businesses/*", "GetBusinessGet"},
+	{"PATCH", "/businesses/*", "UpdateBusinessPatch"},
+	{"POST", "/businesses/*/archive", "ArchiveBusinessPost"},
+	{"GET", "/businesses/*/daily_availabilities", "ListDailyAvailabilitiesForBusinessGet"},
+	{"GET", "/businesses/*/practitioners", "ListPractitionersForBusinessGet"},
+	{"GET", "/businesses/*/practitioners/*/appointment_types/*/available_times", "GetAllAvailableTimesGet"},
+	{"GET", "/businesses/*/practitioners/*/appointment_types/*/next_available_time", "GetNextAvailableTimeGet"},
+	{"GET", "/businesses/*/practitioners/inactive", "ListInactivePractitionersForBusinessGet"},
+	{"GET", "/businesses/*/services", "ListServicesForBusinessGet"},
+	{"POST", "/businesses/*/unarchive", "UnarchiveBusinessPost"},
+	{"GET", "/communications", "ListCommunicationsGet"},
+	{"POST", "/communications", "CreateMemoCommunicationPost"},
+	{"GET", "/communications/*", "GetCommunicationGet"},
+	{"PATCH", "/communications/*", "UpdateMemoCommunicationPatch"},
+	{"POST", "/communications/*/archive", "ArchiveMemoCommunicationPost"},
+	{"GET", "/concession_prices", "ListConcessionPricesGet"},
+	{"GET", "/concession_prices/*", "GetConcessionPriceGet"},
+	{"GET", "/concession_types", "ListConcessionTypesGet"},
+	{"POST", "/concession_types", "CreateConcessionTypePost"},
+	{"GET", "/concession_types/*", "GetConcessionTypeGet"},
+	{"PATCH", "/concession_types/*", "UpdateConcessionTypePatch"},
+	{"GET", "/contacts", "ListContactsGet"},
+	{"POST", "/contacts", "CreateContactPost"},
+	{"DELETE", "/contacts/*", "DeleteContactDelete"},
+	{"GET", "/contacts/*", "GetContactGet"},
+	{"PATCH", "/contacts/*", "UpdateContactPatch"},
+	{"POST", "/contacts/*/archive", "ArchiveContactPost"},
+	{"GET", "/daily_availabilities", "ListDailyAvailabilitiesGet"},
+	{"GET", "/daily_availabilities/*", "GetDailyAvailabilityGet"},
+	{"GET", "/group_appointments", "ListGroupAppointmentsGet"},
+	{"POST", "/group_appointments", "CreateGroupAppointmentPost"},
+	{"DELETE", "/group_appointments/*", "DeleteGroupAppointmentDelete"},
+	{"GET", "/group_appointments/*", "GetGroupAppointmentGet"},
+	{"PATCH", "/group_appointments/*", "UpdateGroupAppointmentPatch"},
+	{"POST", "/group_appointments/*/archive", "ArchiveGroupAppointmentPost"},
+	{"GET", "/group_appointments/*/attendees", "ListAttendeesForGroupAppointmentGet"},
+	{"GET", "/group_appointments/*/conflicts", "GetGroupAppointmentConflictsGet"},
+	{"GET", "/individual_appointments", "ListIndividualAppointmentsGet"},
+	{"POST", "/individual_appointments", "CreateIndividualAppointmentPost"},
+	{"DELETE", "/individual_appointments/*", "DeleteIndividualAppointmentDelete"},
+	{"GET", "/individual_appointments/*", "GetIndividualAppointmentGet"},
+	{"PATCH", "/individual_appointments/*", "UpdateIndividualAppointmentPatch"},
+	{"POST", "/individual_appointments/*/archive", "ArchiveIndividualAppointmentPost"},
+	{"GET", "/individual_appointments/*/attendees", "ListAttendeesForIndividualAppointmentGet"},
+	{"PATCH", "/individual_appointments/*/cancel", "CancelIndividualAppointmentPatch"},
+	{"GET", "/individual_appointments/*/conflicts", "GetIndividualAppointmentConflictsGet"},
+	{"GET", "/invoice_items", "ListInvoiceItemsGet"},
+	{"GET", "/invoice_items/*", "GetInvoiceItemGet"},
+	{"GET", "/invoices", "ListInvoicesGet"},
+	{"GET", "/invoices/*", "GetInvoiceGet"},
+	{"GET", "/invoices/*/invoice_items", "ListInvoiceItemsForInvoiceGet"},
+	{"GET", "/medical_alerts", "ListMedicalAlertsGet"},
+	{"POST", "/medical_alerts", "CreateMedicalAlertPost"},
+	{"DELETE", "/medical_alerts/*", "DeleteMedicalAlertDelete"},
+	{"GET", "/medical_alerts/*", "GetMedicalAlertGet"},
+	{"PATCH", "/medical_alerts/*", "UpdateMedicalAlertPatch"},
+	{"POST", "/medical_alerts/*/archive", "ArchiveMedicalAlertPost"},
+	{"GET", "/patient_attachments", "ListPatientAttachmentsGet"},
+	{"POST", "/patient_attachments", "CreateUploadedPatientAttachmentPost"},
+	{"DELETE", "/patient_attachments/*", "DeletePatientAttachmentDelete"},
+	{"GET", "/patient_attachments/*", "GetPatientAttachmentGet"},
+	{"POST", "/patient_attachments/*/archive", "ArchivePatientAttachmentPost"},
+	{"GET", "/patient_cases", "ListPatientCasesGet"},
+	{"POST", "/patient_cases", "CreatePatientCasePost"},
+	{"GET", "/patient_cases/*", "GetPatientCaseGet"},
+	{"PATCH", "/patient_cases/*", "UpdatePatientCasePatch"},
+	{"POST", "/patient_cases/*/archive", "ArchivePatientCasePost"},
+	{"GET", "/patient_cases/*/attendees", "ListAttendeesForPatientCaseGet"},
+	{"GET", "/patient_cases/*/bookings", "ListBookingsForPatientCaseGet"},
+	{"GET", "/patient_cases/*/invoices", "ListInvoicesForPatientCaseGet"},
+	{"GET", "/patient_cases/*/patient_attachments", "ListPatientAttachmentsForPatientCaseGet"},
+	{"GET", "/patient_cases/active", "ListActivePatientCasesGet"},
+	{"GET", "/patient_form_templates", "ListPatientFormTemplatesGet"},
+	{"POST", "/patient_form_templates", "CreatePatientFormTemplatePost"},
+	{"GET", "/patient_form_templates/*", "GetPatientFormTemplateGet"},
+	{"PATCH", "/patient_form_templates/*", "UpdatePatientFormTemplatePatch"},
+	{"POST", "/patient_form_templates/*/archive", "ArchivePatientFormTemplatePost"},
+	{"GET", "/patient_forms", "ListPatientFormsGet"},
+	{"POST", "/patient_forms", "CreatePatientFormPost"},
+	{"GET", "/patient_forms/*", "GetPatientFormGet"},
+	{"PATCH", "/patient_forms/*", "UpdatePatientFormPatch"},
+	{"POST", "/patient_forms/*/archive", "ArchivePatientFormPost"},
+	{"GET", "/patient_forms/*/signatures/*", "GetSignatureGet"},
+	{"GET", "/patients", "ListPatientsGet"},
+	{"POST", "/patients", "CreatePatientPost"},
+	{"DELETE", "/patients/*", "ArchivePatientDelete"},
+	{"GET", "/patients/*", "GetPatientGet"},
+	{"PATCH", "/patients/*", "UpdatePatientPatch"},
+	{"POST", "/patients/*/archive", "ArchivePatientPost"},
+	{"GET", "/patients/*/attachment_presigned_post", "PresignedPostGet"},
+	{"GET", "/patients/*/invoices", "ListInvoicesForPatientGet"},
+	{"GET", "/patients/*/medical_alerts", "ListMedicalAlertsForPatientGet"},
+	{"GET", "/patients/*/patient_attachments", "ListPatientAttachmentsForPatientGet"},
+	{"GET", "/patients/*/referral_source", "GetReferralSourceGet"},
+	{"PATCH", "/patients/*/referral_source", "UpdateReferralSourcePatch"},
+	{"GET", "/patients/*/treatment_notes", "ListTreatmentNotesForPatientGet"},
+	{"POST", "/patients/*/unarchive", "UnarchivePatientPost"},
+	{"GET", "/practitioner_reference_numbers", "ListPractitionerReferenceNumbersGet"},
+	{"POST", "/practitioner_reference_numbers", "CreatePractitionerReferenceNumberPost"},
+	{"DELETE", "/practitioner_reference_numbers/*", "DeletePractitionerReferenceNumberDelete"},
+	{"GET", "/practitioner_reference_numbers/*", "GetPractitionerReferenceNumberGet"},
+	{"PATCH", "/practitioner_reference_numbers/*", "UpdatePractitionerReferenceNumberPatch"},
+	{"GET", "/practitioners", "ListPractitionersGet"},
+	{"GET", "/practitioners/*", "GetPractitionerGet"},
+	{"GET", "/practitioners/*/appointment_types", "ListAppointmentTypesForPractitionerGet"},
+	{"GET", "/practitioners/*/daily_availabilities", "ListDailyAvailabilitiesForPractitionerGet"},
+	{"GET", "/practitioners/*/invoices", "ListInvoicesForPractitionerGet"},
+	{"GET", "/practitioners/*/practitioner_reference_numbers", "ListPractitionerReferenceNumbersForPractitionerGet"},
+	{"GET", "/practitioners/inactive", "ListInactivePractitionersGet"},
+	{"GET", "/product_suppliers", "ListProductSuppliersGet"},
+	{"POST", "/product_suppliers", "CreateProductSupplierPost"},
+	{"DELETE", "/product_suppliers/*", "DeleteProductSupplierDelete"},
+	{"GET", "/product_suppliers/*", "GetProductSupplierGet"},
+	{"PATCH", "/product_suppliers/*", "UpdateProductSupplierPatch"},
+	{"POST", "/product_suppliers/*/archive", "ArchiveProductSupplierPost"},
+	{"GET", "/products", "ListProductsGet"},
+	{"POST", "/products", "CreateProductPost"},
+	{"DELETE", "/products/*", "DeleteProductDelete"},
+	{"GET", "/products/*", "GetProductGet"},
+	{"PATCH", "/products/*", "UpdateProductPatch"},
+	{"POST", "/products/*/archive", "ArchiveProductPost"},
+	{"GET", "/referral_source_types", "ListReferralSourceTypesGet"},
+	{"GET", "/referral_source_types/*", "GetReferralSourceTypeGet"},
+	{"GET", "/referral_sources", "ListReferralSourcesGet"},
+	{"GET", "/services", "ListServicesGet"},
+	{"GET", "/settings", "GetSettingsGet"},
+	{"GET", "/settings/public", "GetPublicSettingsGet"},
+	{"GET", "/stock_adjustments", "ListStockAdjustmentsGet"},
+	{"POST", "/stock_adjustments", "CreateStockAdjustmentPost"},
+	{"GET", "/stock_adjustments/*", "GetStockAdjustmentGet"},
+	{"GET", "/taxes", "ListTaxesGet"},
+	{"POST", "/taxes", "CreateTaxPost"},
+	{"DELETE", "/taxes/*", "DeleteTaxDelete"},
+	{"GET", "/taxes/*", "GetTaxGet"},
+	{"PATCH", "/taxes/*", "UpdateTaxPatch"},
+	{"GET", "/treatment_note_templates", "ListTreatmentNoteTemplatesGet"},
+	{"POST", "/treatment_note_templates", "CreateTreatmentNoteTemplatePost"},
+	{"DELETE", "/treatment_note_templates/*", "DeleteTreatmentNoteTemplateDelete"},
+	{"GET", "/treatment_note_templates/*", "GetTreatmentNoteTemplateGet"},
+	{"PATCH", "/treatment_note_templates/*", "UpdateTreatmentNoteTemplatePatch"},
+	{"POST", "/treatment_note_templates/*/archive", "ArchiveTreatmentNoteTemplatePost"},
+	{"GET", "/treatment_notes", "ListTreatmentNotesGet"},
+	{"POST", "/treatment_notes", "CreateTreatmentNotePost"},
+	{"DELETE", "/treatment_notes/*", "DeleteTreatmentNoteDelete"},
+	{"GET", "/treatment_notes/*", "GetTreatmentNoteGet"},
+	{"PATCH", "/treatment_notes/*", "UpdateTreatmentNotePatch"},
+	{"POST", "/treatment_notes/*/archive", "ArchiveTreatmentNotePost"},
+	{"GET", "/unavailable_blocks", "ListUnavailableBlocksGet"},
+	{"POST", "/unavailable_blocks", "CreateUnavailableBlockPost"},
+	{"DELETE", "/unavailable_blocks/*", "DeleteUnavailableBlockDelete"},
+	{"GET", "/unavailable_blocks/*", "GetUnavailableBlockGet"},
+	{"PATCH", "/unavailable_blocks/*", "UpdateUnavailableBlockPatch"},
+	{"POST", "/unavailable_blocks/*/archive", "ArchiveUnavailableBlockPost"},
+	{"GET", "/unavailable_blocks/*/conflicts", "GetUnavailableBlockConflictsGet"},
+	{"GET", "/user", "GetAuthenticatedUserGet"},
+	{"GET", "/users", "ListUsersGet"},
+	{"GET", "/users/*", "GetUserGet"},
+}
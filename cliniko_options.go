@@ -0,0 +1,85 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidToken is returned by NewClinikoClient and
+// NewClinikoClientWithOptions for tokens that are not
+// valid Cliniko API keys
+var ErrInvalidToken = errors.New("cliniko: invalid api token")
+
+// shardPattern matches shard names such as au1 or uk2
+var shardPattern = regexp.MustCompile(`^[a-z]{2}[0-9]+$`)
+
+// ClinikoClientOption configures a ClinikoClient. Both
+// ClientOption values, e.g. WithBaseURL, WithShard or
+// WithHTTPDoer, and RequestEditorFn values are accepted.
+type ClinikoClientOption interface {
+	applyTo(c *Client) error
+}
+
+func (o ClientOption) applyTo(c *Client) error {
+	return o(c)
+}
+
+func (fn RequestEditorFn) applyTo(c *Client) error {
+	c.RequestEditors = append(c.RequestEditors, fn)
+	return nil
+}
+
+// WithShard sends all requests to the given shard, e.g. "uk1",
+// instead of the shard deduced from the token
+func WithShard(shard string) ClientOption {
+	return func(c *Client) error {
+		if !shardPattern.MatchString(shard) {
+			return fmt.Errorf("cliniko: invalid shard %q", shard)
+		}
+
+		c.Server = shardURL(shard)
+		return nil
+	}
+}
+
+// WithHTTPDoer sets the Doer used to perform requests,
+// e.g. a RateLimitedDoer or RetryDoer
+func WithHTTPDoer(doer HttpRequestDoer) ClientOption {
+	return WithHTTPClient(doer)
+}
+
+// shardURL returns the API base URL of a shard
+func shardURL(shard string) string {
+	return fmt.Sprintf("https://api.%s.cliniko.com/v1", shard)
+}
+
+// tokenShard validates the token and returns the
+// shard given in its suffix, e.g. "au2" for "MS0x...-au2"
+func tokenShard(token string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("%w: token is empty", ErrInvalidToken)
+	}
+
+	if strings.ContainsAny(token, " \t\r\n:") {
+		return "", fmt.Errorf("%w: token contains whitespace or colons", ErrInvalidToken)
+	}
+
+	separator := strings.LastIndex(token, "-")
+	if separator < 0 {
+		return "", fmt.Errorf(
+			"%w: token has no shard suffix, use WithShard or WithBaseURL",
+			ErrInvalidToken,
+		)
+	}
+
+	key, shard := token[:separator], token[separator+1:]
+	if key == "" || !shardPattern.MatchString(shard) {
+		return "", fmt.Errorf("%w: malformed shard suffix %q", ErrInvalidToken, shard)
+	}
+	return shard, nil
+}
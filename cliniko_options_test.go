@@ -0,0 +1,81 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNewClinikoClientRequestEditors(t *testing.T) {
+	called := false
+	editors := []RequestEditorFn{
+		func(ctx context.Context, req *http.Request) error {
+			called = true
+			return nil
+		},
+	}
+
+	client, err := NewClinikoClient("MS0xLWFiYw-uk2", "vendor", "vendor email", editors...)
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	if client.Client.Server != "https://api.uk2.cliniko.com/v1/" {
+		t.Errorf("Server = %q, want the uk2 shard", client.Client.Server)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, client.Client.Server, nil)
+	for _, fn := range client.Client.RequestEditors {
+		if err := fn(context.Background(), req); err != nil {
+			t.Fatalf("RequestEditor: %v", err)
+		}
+	}
+	if !called {
+		t.Errorf("request editor passed to NewClinikoClient was not added")
+	}
+}
+
+func TestTokenShard(t *testing.T) {
+	tests := []struct {
+		token string
+		shard string
+		valid bool
+	}{
+		{"MS0xLWFiYw-au1", "au1", true},
+		{"MS0x-LWFi-uk2", "uk2", true},
+		{"MS0xLWFiYw", "", false},
+		{"", "", false},
+		{"MS0xLWFiYw-", "", false},
+		{"-au1", "", false},
+		{"MS0x LWFiYw-au1", "", false},
+		{"MS0xLWFiYw-AU1", "", false},
+	}
+
+	for _, tt := range tests {
+		shard, err := tokenShard(tt.token)
+		if tt.valid && (err != nil || shard != tt.shard) {
+			t.Errorf("tokenShard(%q) = %q, %v, want %q", tt.token, shard, err, tt.shard)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("tokenShard(%q) error = %v, want ErrInvalidToken", tt.token, err)
+		}
+	}
+}
+
+func TestNewClinikoClientWithOptions(t *testing.T) {
+	client, err := NewClinikoClientWithOptions("token", "vendor", "vendor email",
+		WithBaseURL("https://proxy.example.com/v1"))
+	if err != nil {
+		t.Fatalf("NewClinikoClientWithOptions: %v", err)
+	}
+	if client.Client.Server != "https://proxy.example.com/v1/" {
+		t.Errorf("Server = %q, want the base URL", client.Client.Server)
+	}
+
+	if _, err := NewClinikoClientWithOptions("token-au1", "vendor", "vendor email", WithShard("AU")); err == nil {
+		t.Errorf("invalid shard was accepted")
+	}
+}
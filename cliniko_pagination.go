@@ -0,0 +1,201 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// PageFetcher performs the request for the first page
+// of a list endpoint, e.g. a call to Client.ListPatientsGet
+type PageFetcher func(ctx context.Context) (*http.Response, error)
+
+// Iterator yields the items of a paginated list endpoint
+// one by one. It follows links.next of every page until the
+// last page has been read, an error occurred or the context
+// has been cancelled:
+//
+//	it := client.IteratePatients(ctx, &ListPatientsGetParams{})
+//	for it.Next() {
+//		patient := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx        context.Context
+	c          *ClinikoClient
+	key        string
+	first      PageFetcher
+	reqEditors []RequestEditorFn
+
+	started      bool
+	nextLink     *string
+	items        []T
+	pos          int
+	item         T
+	totalEntries int
+	err          error
+}
+
+// Paginate returns an Iterator over the items stored under key
+// in the JSON200 body of a list endpoint. The first page is
+// requested with first, so the page size given in its params
+// (PerPage) applies to every page. All subsequent pages are
+// requested from the links.next URL returned by the API.
+func Paginate[T any](
+	ctx context.Context,
+	c *ClinikoClient,
+	key string,
+	first PageFetcher,
+	reqEditors ...RequestEditorFn,
+) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:        ctx,
+		c:          c,
+		key:        key,
+		first:      first,
+		reqEditors: reqEditors,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the
+// next page if required. It returns false once all items
+// have been read or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.pos >= len(it.items) {
+		if it.started && it.nextLink == nil {
+			return false
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.item = it.items[it.pos]
+	it.pos++
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the first error encountered while iterating
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// TotalEntries returns the total number of items as reported
+// by the API. It is only valid after the first call to Next.
+func (it *Iterator[T]) TotalEntries() int {
+	return it.totalEntries
+}
+
+// All drains the iterator and returns every remaining item
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+func (it *Iterator[T]) fetchPage() error {
+	var (
+		rsp *http.Response
+		err error
+	)
+
+	if !it.started {
+		rsp, err = it.first(it.ctx)
+	} else {
+		rsp, err = it.c.getLink(it.ctx, *it.nextLink, it.reqEditors...)
+	}
+	if err != nil {
+		return err
+	}
+	it.started = true
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return err
+	}
+
+	if err := ResponseError(rsp, bodyBytes); err != nil {
+		return err
+	}
+
+	var page map[string]json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &page); err != nil {
+		return err
+	}
+
+	var links struct {
+		Next *string `json:"next,omitempty"`
+	}
+	if raw, ok := page["links"]; ok {
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return err
+		}
+	}
+
+	if raw, ok := page["total_entries"]; ok {
+		if err := json.Unmarshal(raw, &it.totalEntries); err != nil {
+			return err
+		}
+	}
+
+	var items []T
+	if raw, ok := page[it.key]; ok {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+	}
+
+	it.items = items
+	it.pos = 0
+	it.nextLink = links.Next
+	if len(items) == 0 {
+		it.nextLink = nil
+	}
+	return nil
+}
+
+// getLink performs an authenticated GET request against an
+// absolute URL returned by the API, e.g. links.next
+func (c *ClinikoClient) getLink(
+	ctx context.Context,
+	link string,
+	reqEditors ...RequestEditorFn,
+) (
+	*http.Response, error,
+) {
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	if err := c.Client.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Client.Do(req)
+}
@@ -0,0 +1,254 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is the per user rate limit
+// enforced by the Cliniko API
+const DefaultRequestsPerMinute = 200
+
+// DefaultRateLimitRetries is the number of times a request
+// is repeated after the API responded with 429 Too Many Requests
+const DefaultRateLimitRetries = 3
+
+// RateLimitUsage describes the current usage of the
+// requests-per-minute budget of a RateLimitedDoer
+type RateLimitUsage struct {
+	// Limit is the number of requests allowed per window
+	Limit int
+	// Used is the number of requests sent within the current window
+	Used int
+	// Remaining is the number of requests that can be sent
+	// without waiting
+	Remaining int
+	// ResetAt is the time at which the oldest request of the
+	// current window expires and frees up budget
+	ResetAt time.Time
+	// BlockedUntil is set when the API responded with 429
+	// and a Retry-After that has not yet passed
+	BlockedUntil time.Time
+}
+
+// RateLimitedDoer wraps a HttpRequestDoer and throttles
+// outgoing requests to a requests-per-minute budget. When
+// the API responds with 429 Too Many Requests, all requests
+// are paused for the duration given in the Retry-After header
+// and the request is sent again.
+//
+// Install it with WithHTTPClient or by replacing the Doer
+// of an existing client:
+//
+//	client.Client.Client = NewRateLimitedDoer(
+//		client.Client.Client,
+//		DefaultRequestsPerMinute,
+//	)
+type RateLimitedDoer struct {
+	// MaxRetries is the number of times a request is
+	// repeated after a 429 response, 0 disables retrying
+	MaxRetries int
+
+	doer   HttpRequestDoer
+	limit  int
+	window time.Duration
+
+	mu           sync.Mutex
+	sent         []time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimitedDoer creates a RateLimitedDoer that sends at
+// most requestsPerMinute requests per minute through doer.
+// A nil doer defaults to an http.Client using CheckRedirect.
+func NewRateLimitedDoer(
+	doer HttpRequestDoer,
+	requestsPerMinute int,
+) *RateLimitedDoer {
+	if doer == nil {
+		doer = newHTTPClient()
+	}
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = DefaultRequestsPerMinute
+	}
+
+	return &RateLimitedDoer{
+		MaxRetries: DefaultRateLimitRetries,
+		doer:       doer,
+		limit:      requestsPerMinute,
+		window:     time.Minute,
+	}
+}
+
+// Do sends the request once budget is available and
+// implements HttpRequestDoer
+func (d *RateLimitedDoer) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := d.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		rsp, err := d.doer.Do(req)
+		if err != nil || rsp.StatusCode != http.StatusTooManyRequests {
+			return rsp, err
+		}
+
+		retryAfter := parseRetryAfter(rsp.Header.Get("Retry-After"), time.Now())
+		d.block(retryAfter)
+
+		if attempt >= d.MaxRetries {
+			return rsp, nil
+		}
+
+		next, err := rewindRequest(req)
+		if err != nil {
+			// the body can not be sent again, leave
+			// handling of the 429 to the caller
+			return rsp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, rsp.Body)
+		_ = rsp.Body.Close()
+		req = next
+	}
+}
+
+// Usage reports the current usage of the request budget
+func (d *RateLimitedDoer) Usage() RateLimitUsage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.expire(now)
+
+	usage := RateLimitUsage{
+		Limit:     d.limit,
+		Used:      len(d.sent),
+		Remaining: d.limit - len(d.sent),
+		ResetAt:   now,
+	}
+	if len(d.sent) > 0 {
+		usage.ResetAt = d.sent[0].Add(d.window)
+	}
+	if d.blockedUntil.After(now) {
+		usage.BlockedUntil = d.blockedUntil
+	}
+	return usage
+}
+
+// wait blocks until a request may be sent and
+// reserves a slot in the current window for it
+func (d *RateLimitedDoer) wait(ctx context.Context) error {
+	for {
+		d.mu.Lock()
+		now := time.Now()
+		d.expire(now)
+
+		var delay time.Duration
+		switch {
+		case d.blockedUntil.After(now):
+			delay = d.blockedUntil.Sub(now)
+		case len(d.sent) >= d.limit:
+			delay = d.sent[0].Add(d.window).Sub(now)
+		default:
+			d.sent = append(d.sent, now)
+			d.mu.Unlock()
+			return nil
+		}
+		d.mu.Unlock()
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// block pauses all requests for the given duration
+func (d *RateLimitedDoer) block(duration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	until := time.Now().Add(duration)
+	if until.After(d.blockedUntil) {
+		d.blockedUntil = until
+	}
+}
+
+// expire drops all requests that fell out of the window,
+// the caller must hold d.mu
+func (d *RateLimitedDoer) expire(now time.Time) {
+	cutoff := now.Add(-d.window)
+	i := 0
+	for i < len(d.sent) && !d.sent[i].After(cutoff) {
+		i++
+	}
+	d.sent = d.sent[i:]
+}
+
+// parseRetryAfter parses the value of a Retry-After header,
+// which is either a number of seconds or an HTTP date.
+// If the header is missing or invalid a delay of one
+// second is assumed.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return time.Second
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+		return 0
+	}
+
+	return time.Second
+}
+
+// rewindRequest returns a copy of req with a fresh body
+// so that it can be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body can not be rewound")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+	return next, nil
+}
+
+// sleep waits for the given duration or until ctx is done
+func sleep(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
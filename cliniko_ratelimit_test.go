@@ -0,0 +1,192 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statusDoer answers with the given statuses in turn, the
+// last one repeatedly, and counts the requests
+type statusDoer struct {
+	statuses   []int
+	retryAfter string
+
+	mu    sync.Mutex
+	count int
+}
+
+func (d *statusDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	status := d.statuses[len(d.statuses)-1]
+	if d.count < len(d.statuses) {
+		status = d.statuses[d.count]
+	}
+	d.count++
+	d.mu.Unlock()
+
+	header := http.Header{}
+	if status == http.StatusTooManyRequests && d.retryAfter != "" {
+		header.Set("Retry-After", d.retryAfter)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"missing", "", time.Second},
+		{"seconds", "30", 30 * time.Second},
+		{"zero", "0", 0},
+		{"negative", "-5", time.Second},
+		{"http date", "Mon, 01 Jan 2024 09:00:45 GMT", 45 * time.Second},
+		{"http date passed", "Mon, 01 Jan 2024 08:59:00 GMT", 0},
+		{"invalid", "soon", time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitedDoerRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		wantStatus int
+		wantCount  int
+	}{
+		{"success", []int{http.StatusOK}, 3, http.StatusOK, 1},
+		{"retried", []int{http.StatusTooManyRequests, http.StatusOK}, 3, http.StatusOK, 2},
+		{"retries exhausted", []int{http.StatusTooManyRequests}, 2, http.StatusTooManyRequests, 3},
+		{"retrying disabled", []int{http.StatusTooManyRequests}, 0, http.StatusTooManyRequests, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := &statusDoer{statuses: tt.statuses, retryAfter: "0"}
+			doer := NewRateLimitedDoer(origin, DefaultRequestsPerMinute)
+			doer.MaxRetries = tt.maxRetries
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.au1.cliniko.com/v1/patients", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rsp, err := doer.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			_ = rsp.Body.Close()
+
+			if rsp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rsp.StatusCode, tt.wantStatus)
+			}
+			if origin.count != tt.wantCount {
+				t.Errorf("%d requests sent, want %d", origin.count, tt.wantCount)
+			}
+			if usage := doer.Usage(); usage.Used != tt.wantCount {
+				t.Errorf("Used = %d, want %d", usage.Used, tt.wantCount)
+			}
+		})
+	}
+}
+
+// TestRateLimitedDoerRetryAfter blocks all requests for the
+// duration of the Retry-After header of a 429 response
+func TestRateLimitedDoerRetryAfter(t *testing.T) {
+	origin := &statusDoer{statuses: []int{http.StatusTooManyRequests}, retryAfter: "60"}
+	doer := NewRateLimitedDoer(origin, DefaultRequestsPerMinute)
+	doer.MaxRetries = 0
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.au1.cliniko.com/v1/patients", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	rsp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_ = rsp.Body.Close()
+
+	blocked := doer.Usage().BlockedUntil
+	if blocked.Before(start.Add(59*time.Second)) || blocked.After(time.Now().Add(60*time.Second)) {
+		t.Errorf("BlockedUntil = %v, want a minute after %v", blocked, start)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := doer.Do(req.WithContext(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do while blocked = %v, want context.DeadlineExceeded", err)
+	}
+	if origin.count != 1 {
+		t.Errorf("%d requests sent while blocked, want 1", origin.count)
+	}
+}
+
+// TestRateLimitedDoerWindow waits for the oldest request
+// to leave the window once the budget is used up
+func TestRateLimitedDoerWindow(t *testing.T) {
+	origin := &statusDoer{statuses: []int{http.StatusOK}}
+	doer := NewRateLimitedDoer(origin, 2)
+	doer.window = 100 * time.Millisecond
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.au1.cliniko.com/v1/patients", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func() time.Duration {
+		start := time.Now()
+		rsp, err := doer.Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		_ = rsp.Body.Close()
+		return time.Since(start)
+	}
+
+	start := time.Now()
+	send()
+	send()
+	usage := doer.Usage()
+	if usage.Limit != 2 || usage.Used != 2 || usage.Remaining != 0 {
+		t.Errorf("usage = %+v, want the budget used up", usage)
+	}
+	if usage.ResetAt.Before(start.Add(doer.window)) {
+		t.Errorf("ResetAt = %v, want after %v", usage.ResetAt, start.Add(doer.window))
+	}
+
+	if waited := send(); waited < 50*time.Millisecond {
+		t.Errorf("third request waited %v, want until the window moved", waited)
+	}
+	if origin.count != 3 {
+		t.Errorf("%d requests sent, want 3", origin.count)
+	}
+
+	time.Sleep(doer.window)
+	if usage := doer.Usage(); usage.Used != 0 || usage.Remaining != 2 {
+		t.Errorf("usage = %+v, want the window expired", usage)
+	}
+}
@@ -0,0 +1,238 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DefaultResolveConcurrency is the number of records
+// ResolveAll fetches at the same time
+const DefaultResolveConcurrency = 4
+
+// linkField is the {"links": {"self": ...}} object
+// the API embeds for records linked from a model
+type linkField = struct {
+	Links *struct {
+		Self *string `json:"self,omitempty"`
+	} `json:"links,omitempty"`
+}
+
+// linkTypes maps the collection in the path of a links.self
+// URL to the model returned by its Get*Get endpoint. Models get
+// a Resolve* helper for every link to a single record of these
+// collections. Links to lists, e.g. Patient.Invoices, are read
+// with the Iterate* functions instead, and the following links
+// to single records have no helper:
+//
+//   - EmailCommunication and SmsCommunication Letter and Payment,
+//     the API has no endpoints for letters and payments
+//   - ReferralSource.Referrer, it points to a contact, patient
+//     or practitioner, use Resolve with the matching type
+//   - the Content of attachments, which is not JSON, use the
+//     DownloadAttachment or OpenAttachment
+var linkTypes = map[string]string{
+	"appointment_types":              "AppointmentType",
+	"appointments":                   "Booking",
+	"attendees":                      "Attendee",
+	"availability_blocks":            "AvailabilityBlock",
+	"billable_items":                 "BillableItem",
+	"bookings":                       "Booking",
+	"businesses":                     "Business",
+	"communications":                 "Communication",
+	"concession_prices":              "ConcessionPrice",
+	"concession_types":               "ConcessionType",
+	"contacts":                       "Contact",
+	"daily_availabilities":           "DailyAvailability",
+	"group_appointments":             "GroupAppointment",
+	"individual_appointments":        "IndividualAppointment",
+	"invoice_items":                  "InvoiceItem",
+	"invoices":                       "Invoice",
+	"medical_alerts":                 "MedicalAlert",
+	"patient_attachments":            "PatientAttachment",
+	"patient_cases":                  "PatientCase",
+	"patient_form_templates":         "PatientFormTemplate",
+	"patient_forms":                  "PatientForm",
+	"patients":                       "Patient",
+	"practitioner_reference_numbers": "PractitionerReferenceNumber",
+	"practitioners":                  "Practitioner",
+	"product_suppliers":              "ProductSupplier",
+	"products":                       "Product",
+	"referral_source_types":          "ReferralSourceType",
+	"stock_adjustments":              "StockAdjustment",
+	"taxes":                          "Tax",
+	"treatment_note_templates":       "TreatmentNoteTemplate",
+	"treatment_notes":                "TreatmentNote",
+	"unavailable_blocks":             "UnavailableBlock",
+	"users":                          "User",
+}
+
+// linkAliases maps collections that are linked but have no
+// Get*Get endpoint to the collection serving their records.
+// Invoice.Appointment links to /appointments/{id}, the legacy
+// path of appointments that are served as bookings.
+var linkAliases = map[string]string{
+	"appointments": "bookings",
+}
+
+// selfLink returns the URL of a linked record or nil
+func selfLink(field *linkField) *string {
+	if field == nil || field.Links == nil {
+		return nil
+	}
+	return field.Links.Self
+}
+
+// ParseLink splits a links.self URL of the client's server
+// into collection and id, e.g. "patients" and "1". Links to
+// other hosts are rejected so that the token is not leaked.
+func (c *ClinikoClient) ParseLink(link string) (string, string, error) {
+	server, err := url.Parse(c.Client.Server)
+	if err != nil {
+		return "", "", err
+	}
+	return splitLink(link, server)
+}
+
+// Resolve fetches the record a links.self URL points to, e.g.
+//
+//	patient, err := Resolve[Patient](ctx, client, *invoice.Patient.Links.Self)
+//
+// The link must point to a record of type T on the client's server.
+// Links to /appointments/{id} are resolved as a Booking.
+func Resolve[T any](
+	ctx context.Context,
+	c *ClinikoClient,
+	link string,
+	reqEditors ...RequestEditorFn,
+) (
+	T, error,
+) {
+	var record T
+	collection, id, err := c.ParseLink(link)
+	if err != nil {
+		return record, err
+	}
+
+	want := reflect.TypeOf(record).Name()
+	if linkTypes[collection] != want {
+		return record, fmt.Errorf("cliniko: link %q does not point to a %s", link, want)
+	}
+
+	if alias, ok := linkAliases[collection]; ok {
+		link = strings.TrimSuffix(link, collection+"/"+id) + alias + "/" + id
+	}
+
+	rsp, err := c.getLink(ctx, link, reqEditors...)
+	if err != nil {
+		return record, err
+	}
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return record, err
+	}
+
+	if err := ResponseError(rsp, bodyBytes); err != nil {
+		return record, err
+	}
+
+	err = json.Unmarshal(bodyBytes, &record)
+	return record, err
+}
+
+// ResolveAll fetches the records of all links, each distinct
+// link only once, and returns them by link. Up to
+// DefaultResolveConcurrency records are fetched at a time,
+// the first error cancels the remaining requests.
+func ResolveAll[T any](
+	ctx context.Context,
+	c *ClinikoClient,
+	links []string,
+	reqEditors ...RequestEditorFn,
+) (
+	map[string]T, error,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pending := make(chan string)
+	go func() {
+		defer close(pending)
+		seen := make(map[string]bool, len(links))
+		for _, link := range links {
+			if seen[link] {
+				continue
+			}
+			seen[link] = true
+
+			select {
+			case pending <- link:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		records  = make(map[string]T, len(links))
+		firstErr error
+	)
+	for i := 0; i < DefaultResolveConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range pending {
+				record, err := Resolve[T](ctx, c, link, reqEditors...)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				} else if err == nil {
+					records[link] = record
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return records, nil
+}
+
+// resolveField resolves a link embedded in a model,
+// returning nil if the link is not set
+func resolveField[T any](
+	ctx context.Context,
+	c *ClinikoClient,
+	field *linkField,
+	reqEditors []RequestEditorFn,
+) (
+	*T, error,
+) {
+	link := selfLink(field)
+	if link == nil {
+		return nil, nil
+	}
+
+	record, err := Resolve[T](ctx, c, *link, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
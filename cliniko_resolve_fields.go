@@ -0,0 +1,894 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import "context"
+
+// ResolveBillableItem fetches the BillableItem linked by BillableItem,
+// or returns nil if no link is set
+func (at AppointmentType) ResolveBillableItem(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*BillableItem, error,
+) {
+	return resolveField[BillableItem](ctx, c, at.BillableItem, reqEditors)
+}
+
+// ResolveProduct fetches the Product linked by Product,
+// or returns nil if no link is set
+func (at AppointmentType) ResolveProduct(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Product, error,
+) {
+	return resolveField[Product](ctx, c, at.Product, reqEditors)
+}
+
+// ResolveTreatmentNoteTemplate fetches the TreatmentNoteTemplate linked by TreatmentNoteTemplate,
+// or returns nil if no link is set
+func (at AppointmentType) ResolveTreatmentNoteTemplate(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNoteTemplate, error,
+) {
+	return resolveField[TreatmentNoteTemplate](ctx, c, at.TreatmentNoteTemplate, reqEditors)
+}
+
+// ResolveBooking fetches the Booking linked by Booking,
+// or returns nil if no link is set
+func (a Attendee) ResolveBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, a.Booking, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (a Attendee) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, a.Patient, reqEditors)
+}
+
+// ResolvePatientCase fetches the PatientCase linked by PatientCase,
+// or returns nil if no link is set
+func (a Attendee) ResolvePatientCase(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientCase, error,
+) {
+	return resolveField[PatientCase](ctx, c, a.PatientCase, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (ab AvailabilityBlock) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, ab.Business, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (ab AvailabilityBlock) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, ab.Practitioner, reqEditors)
+}
+
+// ResolveTax fetches the Tax linked by Tax,
+// or returns nil if no link is set
+func (bi BillableItem) ResolveTax(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Tax, error,
+) {
+	return resolveField[Tax](ctx, c, bi.Tax, reqEditors)
+}
+
+// ResolveBillableItem fetches the BillableItem linked by BillableItem,
+// or returns nil if no link is set
+func (cp ConcessionPrice) ResolveBillableItem(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*BillableItem, error,
+) {
+	return resolveField[BillableItem](ctx, c, cp.BillableItem, reqEditors)
+}
+
+// ResolveConcessionType fetches the ConcessionType linked by ConcessionType,
+// or returns nil if no link is set
+func (cp ConcessionPrice) ResolveConcessionType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*ConcessionType, error,
+) {
+	return resolveField[ConcessionType](ctx, c, cp.ConcessionType, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (da DailyAvailability) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, da.Business, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (da DailyAvailability) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, da.Practitioner, reqEditors)
+}
+
+// ResolveAttendee fetches the Attendee linked by Attendee,
+// or returns nil if no link is set
+func (ec EmailCommunication) ResolveAttendee(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	return resolveField[Attendee](ctx, c, ec.Attendee, reqEditors)
+}
+
+// ResolveBooking fetches the Booking linked by Booking,
+// or returns nil if no link is set
+func (ec EmailCommunication) ResolveBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, ec.Booking, reqEditors)
+}
+
+// ResolveInvoice fetches the Invoice linked by Invoice,
+// or returns nil if no link is set
+func (ec EmailCommunication) ResolveInvoice(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Invoice, error,
+) {
+	return resolveField[Invoice](ctx, c, ec.Invoice, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (ec EmailCommunication) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, ec.Patient, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (fpe FullPatientExport) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, fpe.Patient, reqEditors)
+}
+
+// ResolveUser fetches the User linked by User,
+// or returns nil if no link is set
+func (fpe FullPatientExport) ResolveUser(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	return resolveField[User](ctx, c, fpe.User, reqEditors)
+}
+
+// ResolveAppointmentType fetches the AppointmentType linked by AppointmentType,
+// or returns nil if no link is set
+func (ga GroupAppointment) ResolveAppointmentType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*AppointmentType, error,
+) {
+	return resolveField[AppointmentType](ctx, c, ga.AppointmentType, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (ga GroupAppointment) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, ga.Business, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (ga GroupAppointment) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, ga.Practitioner, reqEditors)
+}
+
+// ResolveRepeatedFrom fetches the GroupAppointment linked by RepeatedFrom,
+// or returns nil if no link is set
+func (ga GroupAppointment) ResolveRepeatedFrom(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*GroupAppointment, error,
+) {
+	return resolveField[GroupAppointment](ctx, c, ga.RepeatedFrom, reqEditors)
+}
+
+// ResolveAppointmentType fetches the AppointmentType linked by AppointmentType,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolveAppointmentType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*AppointmentType, error,
+) {
+	return resolveField[AppointmentType](ctx, c, ia.AppointmentType, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, ia.Business, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, ia.Patient, reqEditors)
+}
+
+// ResolvePatientCase fetches the PatientCase linked by PatientCase,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolvePatientCase(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*PatientCase, error,
+) {
+	return resolveField[PatientCase](ctx, c, ia.PatientCase, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, ia.Practitioner, reqEditors)
+}
+
+// ResolveRepeatedFrom fetches the IndividualAppointment linked by RepeatedFrom,
+// or returns nil if no link is set
+func (ia IndividualAppointment) ResolveRepeatedFrom(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*IndividualAppointment, error,
+) {
+	return resolveField[IndividualAppointment](ctx, c, ia.RepeatedFrom, reqEditors)
+}
+
+// ResolveAppointment fetches the Booking linked by Appointment,
+// or returns nil if no link is set
+func (i Invoice) ResolveAppointment(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, i.Appointment, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (i Invoice) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, i.Business, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (i Invoice) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, i.Patient, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (i Invoice) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, i.Practitioner, reqEditors)
+}
+
+// ResolveBillableItem fetches the BillableItem linked by BillableItem,
+// or returns nil if no link is set
+func (ii InvoiceItem) ResolveBillableItem(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*BillableItem, error,
+) {
+	return resolveField[BillableItem](ctx, c, ii.BillableItem, reqEditors)
+}
+
+// ResolveInvoice fetches the Invoice linked by Invoice,
+// or returns nil if no link is set
+func (ii InvoiceItem) ResolveInvoice(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Invoice, error,
+) {
+	return resolveField[Invoice](ctx, c, ii.Invoice, reqEditors)
+}
+
+// ResolveProduct fetches the Product linked by Product,
+// or returns nil if no link is set
+func (ii InvoiceItem) ResolveProduct(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Product, error,
+) {
+	return resolveField[Product](ctx, c, ii.Product, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (ma MedicalAlert) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, ma.Patient, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (mc MemoCommunication) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, mc.Patient, reqEditors)
+}
+
+// ResolveConcessionType fetches the ConcessionType linked by ConcessionType,
+// or returns nil if no link is set
+func (p Patient) ResolveConcessionType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*ConcessionType, error,
+) {
+	return resolveField[ConcessionType](ctx, c, p.ConcessionType, reqEditors)
+}
+
+// ResolveLatestBooking fetches the Booking linked by LatestBooking,
+// or returns nil if no link is set
+func (p Patient) ResolveLatestBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, p.LatestBooking, reqEditors)
+}
+
+// ResolveMergedWithPatient fetches the Patient linked by MergedWithPatient,
+// or returns nil if no link is set
+func (p Patient) ResolveMergedWithPatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, p.MergedWithPatient, reqEditors)
+}
+
+// ResolveReferringDoctor fetches the Contact linked by ReferringDoctor,
+// or returns nil if no link is set
+func (p Patient) ResolveReferringDoctor(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Contact, error,
+) {
+	return resolveField[Contact](ctx, c, p.ReferringDoctor, reqEditors)
+}
+
+// ResolveContact fetches the Contact linked by Contact,
+// or returns nil if no link is set
+func (pc PatientCase) ResolveContact(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Contact, error,
+) {
+	return resolveField[Contact](ctx, c, pc.Contact, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (pc PatientCase) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, pc.Patient, reqEditors)
+}
+
+// ResolveAttendee fetches the Attendee linked by Attendee,
+// or returns nil if no link is set
+func (pf PatientForm) ResolveAttendee(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	return resolveField[Attendee](ctx, c, pf.Attendee, reqEditors)
+}
+
+// ResolveBooking fetches the Booking linked by Booking,
+// or returns nil if no link is set
+func (pf PatientForm) ResolveBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, pf.Booking, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (pf PatientForm) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, pf.Patient, reqEditors)
+}
+
+// ResolveDefaultAppointmentType fetches the AppointmentType linked by DefaultAppointmentType,
+// or returns nil if no link is set
+func (p Practitioner) ResolveDefaultAppointmentType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*AppointmentType, error,
+) {
+	return resolveField[AppointmentType](ctx, c, p.DefaultAppointmentType, reqEditors)
+}
+
+// ResolveUser fetches the User linked by User,
+// or returns nil if no link is set
+func (p Practitioner) ResolveUser(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	return resolveField[User](ctx, c, p.User, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (prn PractitionerReferenceNumber) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, prn.Business, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (prn PractitionerReferenceNumber) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, prn.Practitioner, reqEditors)
+}
+
+// ResolveProductSupplier fetches the ProductSupplier linked by ProductSupplier,
+// or returns nil if no link is set
+func (p Product) ResolveProductSupplier(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*ProductSupplier, error,
+) {
+	return resolveField[ProductSupplier](ctx, c, p.ProductSupplier, reqEditors)
+}
+
+// ResolveTax fetches the Tax linked by Tax,
+// or returns nil if no link is set
+func (p Product) ResolveTax(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Tax, error,
+) {
+	return resolveField[Tax](ctx, c, p.Tax, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (rs ReferralSource) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, rs.Patient, reqEditors)
+}
+
+// ResolveReferralSourceType fetches the ReferralSourceType linked by ReferralSourceType,
+// or returns nil if no link is set
+func (rs ReferralSource) ResolveReferralSourceType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*ReferralSourceType, error,
+) {
+	return resolveField[ReferralSourceType](ctx, c, rs.ReferralSourceType, reqEditors)
+}
+
+// ResolveAppointmentType fetches the AppointmentType linked by AppointmentType,
+// or returns nil if no link is set
+func (s Service) ResolveAppointmentType(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*AppointmentType, error,
+) {
+	return resolveField[AppointmentType](ctx, c, s.AppointmentType, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (s Service) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, s.Business, reqEditors)
+}
+
+// ResolveUser fetches the User linked by User,
+// or returns nil if no link is set
+func (s Signature) ResolveUser(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	return resolveField[User](ctx, c, s.User, reqEditors)
+}
+
+// ResolveAttendee fetches the Attendee linked by Attendee,
+// or returns nil if no link is set
+func (sc SmsCommunication) ResolveAttendee(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	return resolveField[Attendee](ctx, c, sc.Attendee, reqEditors)
+}
+
+// ResolveBooking fetches the Booking linked by Booking,
+// or returns nil if no link is set
+func (sc SmsCommunication) ResolveBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, sc.Booking, reqEditors)
+}
+
+// ResolveInvoice fetches the Invoice linked by Invoice,
+// or returns nil if no link is set
+func (sc SmsCommunication) ResolveInvoice(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Invoice, error,
+) {
+	return resolveField[Invoice](ctx, c, sc.Invoice, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (sc SmsCommunication) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, sc.Patient, reqEditors)
+}
+
+// ResolveProduct fetches the Product linked by Product,
+// or returns nil if no link is set
+func (sa StockAdjustment) ResolveProduct(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Product, error,
+) {
+	return resolveField[Product](ctx, c, sa.Product, reqEditors)
+}
+
+// ResolveUser fetches the User linked by User,
+// or returns nil if no link is set
+func (sa StockAdjustment) ResolveUser(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	return resolveField[User](ctx, c, sa.User, reqEditors)
+}
+
+// ResolveAttendee fetches the Attendee linked by Attendee,
+// or returns nil if no link is set
+func (tn TreatmentNote) ResolveAttendee(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Attendee, error,
+) {
+	return resolveField[Attendee](ctx, c, tn.Attendee, reqEditors)
+}
+
+// ResolveBooking fetches the Booking linked by Booking,
+// or returns nil if no link is set
+func (tn TreatmentNote) ResolveBooking(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Booking, error,
+) {
+	return resolveField[Booking](ctx, c, tn.Booking, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (tn TreatmentNote) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, tn.Patient, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (tn TreatmentNote) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, tn.Practitioner, reqEditors)
+}
+
+// ResolveTreatmentNoteTemplate fetches the TreatmentNoteTemplate linked by TreatmentNoteTemplate,
+// or returns nil if no link is set
+func (tn TreatmentNote) ResolveTreatmentNoteTemplate(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNoteTemplate, error,
+) {
+	return resolveField[TreatmentNoteTemplate](ctx, c, tn.TreatmentNoteTemplate, reqEditors)
+}
+
+// ResolveBusiness fetches the Business linked by Business,
+// or returns nil if no link is set
+func (ub UnavailableBlock) ResolveBusiness(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Business, error,
+) {
+	return resolveField[Business](ctx, c, ub.Business, reqEditors)
+}
+
+// ResolvePractitioner fetches the Practitioner linked by Practitioner,
+// or returns nil if no link is set
+func (ub UnavailableBlock) ResolvePractitioner(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Practitioner, error,
+) {
+	return resolveField[Practitioner](ctx, c, ub.Practitioner, reqEditors)
+}
+
+// ResolveRepeatedFrom fetches the UnavailableBlock linked by RepeatedFrom,
+// or returns nil if no link is set
+func (ub UnavailableBlock) ResolveRepeatedFrom(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*UnavailableBlock, error,
+) {
+	return resolveField[UnavailableBlock](ctx, c, ub.RepeatedFrom, reqEditors)
+}
+
+// ResolvePatient fetches the Patient linked by Patient,
+// or returns nil if no link is set
+func (upa UploadedPatientAttachment) ResolvePatient(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*Patient, error,
+) {
+	return resolveField[Patient](ctx, c, upa.Patient, reqEditors)
+}
+
+// ResolveUser fetches the User linked by User,
+// or returns nil if no link is set
+func (upa UploadedPatientAttachment) ResolveUser(
+	ctx context.Context,
+	c *ClinikoClient,
+	reqEditors ...RequestEditorFn,
+) (
+	*User, error,
+) {
+	return resolveField[User](ctx, c, upa.User, reqEditors)
+}
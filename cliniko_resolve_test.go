@@ -0,0 +1,141 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+func TestResolve(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		link     string
+		wantErr  bool
+		notFound bool
+	}{
+		{"patient", srv.BaseURL() + "/patients/" + patientId, false, false},
+		{"missing patient", srv.BaseURL() + "/patients/999", true, true},
+		{"other type", srv.BaseURL() + "/businesses/" + patientId, true, false},
+		{"other host", "https://api.au1.cliniko.com/v1/patients/" + patientId, true, false},
+		{"list", srv.BaseURL() + "/patients", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patient, err := cliniko.Resolve[cliniko.Patient](context.Background(), client, tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve: %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, cliniko.ErrNotFound) != tt.notFound {
+				t.Errorf("Resolve: %v, want not found %v", err, tt.notFound)
+			}
+			if err == nil && (patient.Id == nil || *patient.Id != patientId) {
+				t.Errorf("resolved patient %v, want %s", patient.Id, patientId)
+			}
+		})
+	}
+}
+
+// TestResolveInvoiceAppointment resolves the /appointments/{id}
+// link of an invoice, which has no endpoint of its own
+func TestResolveInvoiceAppointment(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	appointmentId, err := srv.Seed("individual_appointments", map[string]any{
+		"starts_at": "2024-01-01T09:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	invoiceId, err := srv.Seed("invoices", map[string]any{"appointment_id": appointmentId})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	rsp, err := client.GetInvoiceGetWithResponse(context.Background(), invoiceId, nil)
+	if err != nil || rsp.JSON200 == nil {
+		t.Fatalf("GetInvoiceGet: %v %v", rsp.Status(), err)
+	}
+	invoice := rsp.JSON200
+
+	booking, err := invoice.ResolveAppointment(context.Background(), client)
+	if err != nil {
+		t.Fatalf("ResolveAppointment: %v", err)
+	}
+	if booking == nil {
+		t.Fatalf("ResolveAppointment returned no booking")
+	}
+	appointment, err := booking.AsIndividualAppointment()
+	if err != nil || appointment.Id == nil || *appointment.Id != appointmentId {
+		t.Errorf("resolved %v, %v, want individual appointment %s", appointment.Id, err, appointmentId)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	var links []string
+	for i := 0; i < 6; i++ {
+		id, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+		if err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+		link := srv.BaseURL() + "/patients/" + id
+		links = append(links, link, link)
+	}
+
+	var requests atomic.Int64
+	countRequests := func(ctx context.Context, req *http.Request) error {
+		requests.Add(1)
+		return nil
+	}
+
+	patients, err := cliniko.ResolveAll[cliniko.Patient](context.Background(), client, links, countRequests)
+	if err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	if len(patients) != 6 || requests.Load() != 6 {
+		t.Errorf("resolved %d patients with %d requests, want 6 each", len(patients), requests.Load())
+	}
+	for _, link := range links {
+		if patient, ok := patients[link]; !ok || patient.Id == nil {
+			t.Errorf("link %s was not resolved", link)
+		}
+	}
+
+	links = append(links, srv.BaseURL()+"/patients/999")
+	if _, err := cliniko.ResolveAll[cliniko.Patient](context.Background(), client, links); !errors.Is(err, cliniko.ErrNotFound) {
+		t.Errorf("ResolveAll with a missing record: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryBufferSize is the size up to which the default
+// policy buffers request bodies that can not be rewound
+const DefaultRetryBufferSize = 1 << 20
+
+// RetryPolicy configures which requests a RetryDoer repeats
+// and how long it waits between attempts
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including
+	// the first one, values below 2 disable retrying
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, each
+	// subsequent retry doubles it
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration
+	// Methods lists the HTTP methods that are retried
+	Methods map[string]bool
+	// RetryStatus reports whether a response should be
+	// retried, defaults to RetryableStatus
+	RetryStatus func(statusCode int) bool
+	// MaxBufferSize is the size up to which request bodies
+	// without GetBody are buffered in memory to be repeated.
+	// Larger bodies are streamed and sent only once.
+	MaxBufferSize int64
+}
+
+// DefaultRetryPolicy returns a policy that retries GET, PATCH
+// and DELETE requests up to 4 times with a jittered exponential
+// backoff. POST requests are not retried unless enabled with
+// WithPOST or per request with ContextWithRetry.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Methods: map[string]bool{
+			http.MethodGet:    true,
+			http.MethodHead:   true,
+			http.MethodPatch:  true,
+			http.MethodDelete: true,
+		},
+		RetryStatus:   RetryableStatus,
+		MaxBufferSize: DefaultRetryBufferSize,
+	}
+}
+
+// WithPOST returns a copy of the policy that retries POST requests
+func (p RetryPolicy) WithPOST() RetryPolicy {
+	methods := make(map[string]bool, len(p.Methods)+1)
+	for method, retry := range p.Methods {
+		methods[method] = retry
+	}
+	methods[http.MethodPost] = true
+	p.Methods = methods
+	return p
+}
+
+// RetryableStatus reports whether a status code indicates
+// a transient server side error
+func RetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the jittered delay before the given retry
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+type retryContextKey struct{}
+
+// ContextWithRetry enables or disables retrying for all
+// requests made with the returned context, regardless of
+// the method list of the RetryPolicy. Use it to opt in a
+// single POST request, e.g. the upload in CreateAttachment.
+func ContextWithRetry(ctx context.Context, retry bool) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retry)
+}
+
+// RetryDoer wraps a HttpRequestDoer and repeats requests that
+// failed with a connection error or a transient 5xx status.
+// Request bodies are rewound before each attempt. Bodies that
+// can not be rewound are buffered in memory first if they are
+// no larger than MaxBufferSize, otherwise the request is sent
+// once, so that streamed uploads are never read into memory.
+//
+//	client.Client.Client = NewRetryDoer(
+//		client.Client.Client,
+//		DefaultRetryPolicy(),
+//	)
+type RetryDoer struct {
+	// OnRetry is called before a request is repeated with the
+	// number of the upcoming attempt and the failed result
+	OnRetry func(req *http.Request, attempt int, rsp *http.Response, err error)
+
+	doer   HttpRequestDoer
+	policy RetryPolicy
+}
+
+// NewRetryDoer creates a RetryDoer that sends requests
+// through doer according to policy. A nil doer defaults
+// to an http.Client using CheckRedirect.
+func NewRetryDoer(doer HttpRequestDoer, policy RetryPolicy) *RetryDoer {
+	if doer == nil {
+		doer = newHTTPClient()
+	}
+	if policy.RetryStatus == nil {
+		policy.RetryStatus = RetryableStatus
+	}
+
+	return &RetryDoer{
+		doer:   doer,
+		policy: policy,
+	}
+}
+
+// Unwrap returns the Doer requests are sent through
+func (d *RetryDoer) Unwrap() HttpRequestDoer {
+	return d.doer
+}
+
+// Do sends the request and implements HttpRequestDoer
+func (d *RetryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !d.retryable(req) {
+		return d.doer.Do(req)
+	}
+
+	if err := bufferRequestBody(req, d.policy.MaxBufferSize); err != nil {
+		return nil, err
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		rsp, err := d.doer.Do(req)
+		if attempt >= d.policy.MaxAttempts || !d.shouldRetry(ctx, rsp, err) {
+			return rsp, err
+		}
+
+		next, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return rsp, err
+		}
+
+		if d.OnRetry != nil {
+			d.OnRetry(req, attempt+1, rsp, err)
+		}
+
+		if rsp != nil {
+			_, _ = io.Copy(io.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+
+		if err := sleep(ctx, d.policy.backoff(attempt-1)); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// retryable reports whether the request may be repeated
+func (d *RetryDoer) retryable(req *http.Request) bool {
+	if d.policy.MaxAttempts < 2 {
+		return false
+	}
+	if retry, ok := req.Context().Value(retryContextKey{}).(bool); ok {
+		return retry
+	}
+	return d.policy.Methods[req.Method]
+}
+
+// shouldRetry reports whether the result of an attempt is transient
+func (d *RetryDoer) shouldRetry(
+	ctx context.Context,
+	rsp *http.Response,
+	err error,
+) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) &&
+			!errors.Is(err, context.DeadlineExceeded)
+	}
+	return d.policy.RetryStatus(rsp.StatusCode)
+}
+
+// bufferRequestBody reads a body of up to limit bytes that can
+// not be rewound into memory and sets GetBody so that the request
+// can be repeated. Larger bodies are left without GetBody, the
+// bytes read to find out are put back in front of the rest.
+func bufferRequestBody(req *http.Request, limit int64) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+	if limit <= 0 || req.ContentLength > limit {
+		return nil
+	}
+
+	original := req.Body
+	body, err := io.ReadAll(io.LimitReader(original, limit+1))
+	if err != nil {
+		_ = original.Close()
+		return err
+	}
+
+	if int64(len(body)) > limit {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), original), original}
+		return nil
+	}
+	_ = original.Close()
+
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
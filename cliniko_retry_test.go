@@ -0,0 +1,125 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// failingDoer fails the first attempt of every request with
+// a 503 and records the bodies of all attempts
+type failingDoer struct {
+	bodies [][]byte
+}
+
+func (d *failingDoer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	d.bodies = append(d.bodies, body)
+
+	status := http.StatusCreated
+	if len(d.bodies) == 1 {
+		status = http.StatusServiceUnavailable
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func testPresignedPost(t *testing.T) *PresignedPostGetResponse {
+	t.Helper()
+
+	var post AttachmentPresignedPost
+	err := json.Unmarshal([]byte(`{
+		"url": "https://s3.example.com/bucket",
+		"fields": {
+			"acl": "private",
+			"key": "uploads/${filename}",
+			"policy": "policy",
+			"success_action_status": "201",
+			"x-amz-algorithm": "AWS4-HMAC-SHA256",
+			"x-amz-credential": "credential",
+			"x-amz-signature": "signature"
+		}
+	}`), &post)
+	if err != nil {
+		t.Fatalf("decode presigned post: %v", err)
+	}
+	return &PresignedPostGetResponse{JSON200: &post}
+}
+
+func TestRetryRewindsBody(t *testing.T) {
+	content := bytes.Repeat([]byte(`{"first_name":"Jane"}`), 100)
+	c := &ClinikoClient{}
+	post := testPresignedPost(t)
+
+	withBody := func(body io.Reader) func() (*http.Request, error) {
+		return func() (*http.Request, error) {
+			return NewCreatePatientPostRequestWithBody("https://api.au1.cliniko.com/v1", "application/json", body)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		maxBufferSize int64
+		request       func() (*http.Request, error)
+		wantAttempts  int
+	}{
+		{"WithBody rewindable", DefaultRetryBufferSize,
+			withBody(bytes.NewReader(content)), 2},
+		{"WithBody buffered", DefaultRetryBufferSize,
+			withBody(io.MultiReader(bytes.NewReader(content))), 2},
+		{"WithBody above buffer size", 16,
+			withBody(io.MultiReader(bytes.NewReader(content))), 1},
+		{"UploadFileToS3Bucket", 16,
+			func() (*http.Request, error) {
+				return c.NewUploadFileToS3BucketPostRequest(post, "file.txt", bytes.NewReader(content))
+			}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &failingDoer{}
+			policy := DefaultRetryPolicy().WithPOST()
+			policy.BaseDelay = 0
+			policy.MaxBufferSize = tt.maxBufferSize
+
+			req, err := tt.request()
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			rsp, err := NewRetryDoer(doer, policy).Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			_ = rsp.Body.Close()
+
+			if len(doer.bodies) != tt.wantAttempts {
+				t.Fatalf("made %d attempts, want %d", len(doer.bodies), tt.wantAttempts)
+			}
+			if !bytes.Contains(doer.bodies[0], content) {
+				t.Errorf("first attempt does not contain the whole content")
+			}
+			for i, body := range doer.bodies[1:] {
+				if !bytes.Equal(body, doer.bodies[0]) {
+					t.Errorf("attempt %d sent %d bytes that differ from the first attempt", i+2, len(body))
+				}
+			}
+		})
+	}
+}
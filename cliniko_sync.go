@@ -0,0 +1,690 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSyncPageSize is the number of records requested
+// per page while syncing, the maximum allowed by the API
+const DefaultSyncPageSize = 100
+
+// syncOperation is a list operation that can be synced
+// incrementally, name is the path and JSON key of its records
+type syncOperation struct {
+	name string
+	list func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// syncQuery holds the parameters of a page request,
+// records are always sorted by updated_at and id ascending
+type syncQuery struct {
+	page    int
+	perPage int
+	filters *[]string
+}
+
+// syncSort orders records by updated_at with ties broken by id
+var syncSort = Sort{"updated_at", "id"}
+
+// syncOperations maps the list operations that can be synced
+// incrementally to their records and generated list function
+var syncOperations = map[string]syncOperation{
+	"ListAppointmentTypesGet": {"appointment_types", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListAppointmentTypesGetParamsOrderAsc
+		return c.ListAppointmentTypesGet(ctx, &ListAppointmentTypesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListAttendeesGet": {"attendees", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListAttendeesGetParamsOrderAsc
+		return c.ListAttendeesGet(ctx, &ListAttendeesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListAvailabilityBlocksGet": {"availability_blocks", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListAvailabilityBlocksGetParamsOrderAsc
+		return c.ListAvailabilityBlocksGet(ctx, &ListAvailabilityBlocksGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListBillableItemsGet": {"billable_items", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListBillableItemsGetParamsOrderAsc
+		return c.ListBillableItemsGet(ctx, &ListBillableItemsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListBookingsGet": {"bookings", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListBookingsGetParamsOrderAsc
+		return c.ListBookingsGet(ctx, &ListBookingsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListBusinessesGet": {"businesses", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListBusinessesGetParamsOrderAsc
+		return c.ListBusinessesGet(ctx, &ListBusinessesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListCommunicationsGet": {"communications", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListCommunicationsGetParamsOrderAsc
+		return c.ListCommunicationsGet(ctx, &ListCommunicationsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListConcessionPricesGet": {"concession_prices", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListConcessionPricesGetParamsOrderAsc
+		return c.ListConcessionPricesGet(ctx, &ListConcessionPricesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListConcessionTypesGet": {"concession_types", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListConcessionTypesGetParamsOrderAsc
+		return c.ListConcessionTypesGet(ctx, &ListConcessionTypesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListContactsGet": {"contacts", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListContactsGetParamsOrderAsc
+		return c.ListContactsGet(ctx, &ListContactsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListDailyAvailabilitiesGet": {"daily_availabilities", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListDailyAvailabilitiesGetParamsOrderAsc
+		return c.ListDailyAvailabilitiesGet(ctx, &ListDailyAvailabilitiesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListGroupAppointmentsGet": {"group_appointments", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListGroupAppointmentsGetParamsOrderAsc
+		return c.ListGroupAppointmentsGet(ctx, &ListGroupAppointmentsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListIndividualAppointmentsGet": {"individual_appointments", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListIndividualAppointmentsGetParamsOrderAsc
+		return c.ListIndividualAppointmentsGet(ctx, &ListIndividualAppointmentsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListInvoiceItemsGet": {"invoice_items", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListInvoiceItemsGetParamsOrderAsc
+		return c.ListInvoiceItemsGet(ctx, &ListInvoiceItemsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListInvoicesGet": {"invoices", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListInvoicesGetParamsOrderAsc
+		return c.ListInvoicesGet(ctx, &ListInvoicesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListMedicalAlertsGet": {"medical_alerts", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListMedicalAlertsGetParamsOrderAsc
+		return c.ListMedicalAlertsGet(ctx, &ListMedicalAlertsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPatientAttachmentsGet": {"patient_attachments", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPatientAttachmentsGetParamsOrderAsc
+		return c.ListPatientAttachmentsGet(ctx, &ListPatientAttachmentsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPatientCasesGet": {"patient_cases", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPatientCasesGetParamsOrderAsc
+		return c.ListPatientCasesGet(ctx, &ListPatientCasesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPatientFormTemplatesGet": {"patient_form_templates", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPatientFormTemplatesGetParamsOrderAsc
+		return c.ListPatientFormTemplatesGet(ctx, &ListPatientFormTemplatesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPatientFormsGet": {"patient_forms", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPatientFormsGetParamsOrderAsc
+		return c.ListPatientFormsGet(ctx, &ListPatientFormsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPatientsGet": {"patients", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPatientsGetParamsOrderAsc
+		return c.ListPatientsGet(ctx, &ListPatientsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPractitionerReferenceNumbersGet": {"practitioner_reference_numbers", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPractitionerReferenceNumbersGetParamsOrderAsc
+		return c.ListPractitionerReferenceNumbersGet(ctx, &ListPractitionerReferenceNumbersGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListPractitionersGet": {"practitioners", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListPractitionersGetParamsOrderAsc
+		return c.ListPractitionersGet(ctx, &ListPractitionersGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListProductSuppliersGet": {"product_suppliers", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListProductSuppliersGetParamsOrderAsc
+		return c.ListProductSuppliersGet(ctx, &ListProductSuppliersGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListProductsGet": {"products", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListProductsGetParamsOrderAsc
+		return c.ListProductsGet(ctx, &ListProductsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListReferralSourceTypesGet": {"referral_source_types", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListReferralSourceTypesGetParamsOrderAsc
+		return c.ListReferralSourceTypesGet(ctx, &ListReferralSourceTypesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListReferralSourcesGet": {"referral_sources", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListReferralSourcesGetParamsOrderAsc
+		return c.ListReferralSourcesGet(ctx, &ListReferralSourcesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListStockAdjustmentsGet": {"stock_adjustments", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListStockAdjustmentsGetParamsOrderAsc
+		return c.ListStockAdjustmentsGet(ctx, &ListStockAdjustmentsGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListTaxesGet": {"taxes", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListTaxesGetParamsOrderAsc
+		return c.ListTaxesGet(ctx, &ListTaxesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListTreatmentNoteTemplatesGet": {"treatment_note_templates", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListTreatmentNoteTemplatesGetParamsOrderAsc
+		return c.ListTreatmentNoteTemplatesGet(ctx, &ListTreatmentNoteTemplatesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListTreatmentNotesGet": {"treatment_notes", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListTreatmentNotesGetParamsOrderAsc
+		return c.ListTreatmentNotesGet(ctx, &ListTreatmentNotesGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListUnavailableBlocksGet": {"unavailable_blocks", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := ListUnavailableBlocksGetParamsOrderAsc
+		return c.ListUnavailableBlocksGet(ctx, &ListUnavailableBlocksGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+	"ListUsersGet": {"users", func(ctx context.Context, c *Client, q syncQuery, reqEditors ...RequestEditorFn) (*http.Response, error) {
+		order := Asc
+		return c.ListUsersGet(ctx, &ListUsersGetParams{
+			Page: &q.page, PerPage: &q.perPage, Sort: &syncSort, Order: &order, Q: q.filters,
+		}, reqEditors...)
+	}},
+}
+
+// SyncResource describes a list endpoint that
+// can be synced incrementally by updated_at
+type SyncResource struct {
+	// Operation is the list operation, e.g. "ListPatientsGet"
+	Operation string
+	// Name is the path and JSON key of the records, e.g. "patients"
+	Name string
+
+	includeArchived bool
+	includeDeleted  bool
+}
+
+// NewSyncResource returns the SyncResource for a list
+// operation, e.g. "ListPatientsGet"
+func NewSyncResource(operation string) (SyncResource, error) {
+	op, ok := syncOperations[operation]
+	if !ok {
+		return SyncResource{}, fmt.Errorf("cliniko: %s can not be synced", operation)
+	}
+
+	fields := filterFields[operation]
+	_, archived := fields["archived_at"]
+	_, deleted := fields["deleted_at"]
+	return SyncResource{
+		Operation:       operation,
+		Name:            op.name,
+		includeArchived: archived,
+		includeDeleted:  deleted,
+	}, nil
+}
+
+// SyncResources returns every resource that can be synced
+func SyncResources() []SyncResource {
+	operations := sortedKeys(syncOperations)
+	resources := make([]SyncResource, 0, len(operations))
+	for _, operation := range operations {
+		resource, _ := NewSyncResource(operation)
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// SyncEventType is the kind of change of a SyncEvent
+type SyncEventType string
+
+const (
+	// SyncUpsert is emitted for new, updated and archived records
+	SyncUpsert SyncEventType = "upsert"
+	// SyncDelete is emitted for records with deleted_at set
+	SyncDelete SyncEventType = "delete"
+)
+
+// SyncEvent is a single changed record
+type SyncEvent struct {
+	Type      SyncEventType
+	Resource  string
+	Id        string
+	UpdatedAt time.Time
+	Archived  bool
+	// Record is the record as returned by the API, decode it
+	// into the model type of the resource, e.g. Patient
+	Record json.RawMessage
+}
+
+// SyncSink receives the changes of one page of records. The
+// cursor is only advanced once HandleSyncEvents returned nil.
+type SyncSink interface {
+	HandleSyncEvents(ctx context.Context, events []SyncEvent) error
+}
+
+// SyncSinkFunc adapts a function to a SyncSink
+type SyncSinkFunc func(ctx context.Context, events []SyncEvent) error
+
+// HandleSyncEvents calls f
+func (f SyncSinkFunc) HandleSyncEvents(ctx context.Context, events []SyncEvent) error {
+	return f(ctx, events)
+}
+
+// SyncCursor is the high-water mark of a resource, the last
+// record handled ordered by updated_at and id
+type SyncCursor struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Id        string    `json:"id"`
+}
+
+// CheckpointStore persists the cursor of each resource
+type CheckpointStore interface {
+	// LoadCursor returns the cursor of the resource and
+	// false if the resource has never been synced
+	LoadCursor(ctx context.Context, resource string) (SyncCursor, bool, error)
+	// SaveCursor stores the cursor of the resource
+	SaveCursor(ctx context.Context, resource string, cursor SyncCursor) error
+}
+
+// MemoryCheckpointStore keeps cursors in memory
+type MemoryCheckpointStore struct {
+	mu      sync.Mutex
+	cursors map[string]SyncCursor
+}
+
+// NewMemoryCheckpointStore creates an empty MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{cursors: map[string]SyncCursor{}}
+}
+
+// LoadCursor implements CheckpointStore
+func (s *MemoryCheckpointStore) LoadCursor(
+	ctx context.Context,
+	resource string,
+) (SyncCursor, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor, ok := s.cursors[resource]
+	return cursor, ok, nil
+}
+
+// SaveCursor implements CheckpointStore
+func (s *MemoryCheckpointStore) SaveCursor(
+	ctx context.Context,
+	resource string,
+	cursor SyncCursor,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cursors[resource] = cursor
+	return nil
+}
+
+// FileCheckpointStore keeps cursors in a JSON file, which is
+// replaced atomically on every save
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore
+// writing to the given path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// LoadCursor implements CheckpointStore
+func (s *FileCheckpointStore) LoadCursor(
+	ctx context.Context,
+	resource string,
+) (SyncCursor, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return SyncCursor{}, false, err
+	}
+
+	cursor, ok := cursors[resource]
+	return cursor, ok, nil
+}
+
+// SaveCursor implements CheckpointStore
+func (s *FileCheckpointStore) SaveCursor(
+	ctx context.Context,
+	resource string,
+	cursor SyncCursor,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[resource] = cursor
+
+	body, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(body); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileCheckpointStore) read() (map[string]SyncCursor, error) {
+	cursors := map[string]SyncCursor{}
+
+	body, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &cursors); err != nil {
+		return nil, fmt.Errorf("cliniko: corrupt checkpoint file %s: %w", s.path, err)
+	}
+	return cursors, nil
+}
+
+// Syncer mirrors resources incrementally. Each run fetches only
+// the records updated since the stored cursor, ordered by
+// updated_at, hands them to the sink page by page and stores
+// the new cursor after every page, so that an interrupted run
+// resumes where it stopped.
+type Syncer struct {
+	// PageSize is the number of records requested per page
+	PageSize int
+
+	client     *ClinikoClient
+	store      CheckpointStore
+	sink       SyncSink
+	reqEditors []RequestEditorFn
+}
+
+// NewSyncer creates a Syncer that reports changes to sink and
+// persists cursors in store, reqEditors are applied to every request
+func (c *ClinikoClient) NewSyncer(
+	store CheckpointStore,
+	sink SyncSink,
+	reqEditors ...RequestEditorFn,
+) *Syncer {
+	return &Syncer{
+		PageSize:   DefaultSyncPageSize,
+		client:     c,
+		store:      store,
+		sink:       sink,
+		reqEditors: reqEditors,
+	}
+}
+
+// Sync syncs the given resources one after another,
+// all resources if none are given
+func (s *Syncer) Sync(ctx context.Context, resources ...SyncResource) error {
+	if len(resources) == 0 {
+		resources = SyncResources()
+	}
+
+	for _, resource := range resources {
+		if _, err := s.SyncResource(ctx, resource); err != nil {
+			return fmt.Errorf("cliniko: sync of %s failed: %w", resource.Name, err)
+		}
+	}
+	return nil
+}
+
+// SyncResource syncs a single resource and returns
+// the number of events delivered to the sink
+func (s *Syncer) SyncResource(ctx context.Context, resource SyncResource) (int, error) {
+	cursor, _, err := s.store.LoadCursor(ctx, resource.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered, page := 0, 1
+	for {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		records, err := s.fetchPage(ctx, resource, cursor, page)
+		if err != nil {
+			return delivered, err
+		}
+
+		events := make([]SyncEvent, 0, len(records))
+		next := cursor
+		for _, record := range records {
+			event, err := newSyncEvent(resource.Name, record)
+			if err != nil {
+				return delivered, err
+			}
+			if !afterCursor(event, cursor) {
+				continue
+			}
+			events = append(events, event)
+			next = SyncCursor{UpdatedAt: event.UpdatedAt, Id: event.Id}
+		}
+
+		if len(events) > 0 {
+			if err := s.sink.HandleSyncEvents(ctx, events); err != nil {
+				return delivered, err
+			}
+			if err := s.store.SaveCursor(ctx, resource.Name, next); err != nil {
+				return delivered, err
+			}
+			delivered += len(events)
+			cursor = next
+			page = 1
+		} else {
+			// a full page of records sharing the cursor's
+			// updated_at, move on to the next page
+			page++
+		}
+
+		if len(records) < s.pageSize() {
+			return delivered, nil
+		}
+	}
+}
+
+func (s *Syncer) pageSize() int {
+	if s.PageSize <= 0 || s.PageSize > DefaultSyncPageSize {
+		return DefaultSyncPageSize
+	}
+	return s.PageSize
+}
+
+// fetchPage requests the records updated at or after the cursor
+func (s *Syncer) fetchPage(
+	ctx context.Context,
+	resource SyncResource,
+	cursor SyncCursor,
+	page int,
+) (
+	[]json.RawMessage, error,
+) {
+	op, ok := syncOperations[resource.Operation]
+	if !ok {
+		return nil, fmt.Errorf("cliniko: %s can not be synced", resource.Operation)
+	}
+
+	var filters []string
+	if !cursor.UpdatedAt.IsZero() {
+		filters = append(filters, "updated_at:>="+cursor.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+	if resource.includeArchived {
+		filters = append(filters, "archived_at:*")
+	}
+	if resource.includeDeleted {
+		filters = append(filters, "deleted_at:*")
+	}
+
+	q := syncQuery{page: page, perPage: s.pageSize()}
+	if len(filters) > 0 {
+		q.filters = &filters
+	}
+
+	rsp, err := op.list(ctx, s.client.Client, q, s.reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ResponseError(rsp, bodyBytes); err != nil {
+		return nil, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &body); err != nil {
+		return nil, err
+	}
+
+	var records []json.RawMessage
+	if raw, ok := body[resource.Name]; ok {
+		if err := json.Unmarshal(raw, &records); err != nil {
+			return nil, err
+		}
+	}
+
+	// the API sorts by updated_at, but make sure ties are
+	// ordered by id so that the cursor is monotonic
+	sort.SliceStable(records, func(i, j int) bool {
+		a, _ := newSyncEvent(resource.Name, records[i])
+		b, _ := newSyncEvent(resource.Name, records[j])
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		}
+		return compareIds(a.Id, b.Id) < 0
+	})
+	return records, nil
+}
+
+// newSyncEvent classifies a raw record
+func newSyncEvent(resource string, record json.RawMessage) (SyncEvent, error) {
+	var fields struct {
+		Id         *string    `json:"id"`
+		UpdatedAt  *time.Time `json:"updated_at"`
+		ArchivedAt *time.Time `json:"archived_at"`
+		DeletedAt  *time.Time `json:"deleted_at"`
+	}
+	if err := json.Unmarshal(record, &fields); err != nil {
+		return SyncEvent{}, err
+	}
+
+	if fields.Id == nil || fields.UpdatedAt == nil {
+		return SyncEvent{}, fmt.Errorf("cliniko: %s record without id or updated_at", resource)
+	}
+
+	event := SyncEvent{
+		Type:      SyncUpsert,
+		Resource:  resource,
+		Id:        *fields.Id,
+		UpdatedAt: *fields.UpdatedAt,
+		Archived:  fields.ArchivedAt != nil,
+		Record:    record,
+	}
+	if fields.DeletedAt != nil {
+		event.Type = SyncDelete
+	}
+	return event, nil
+}
+
+// afterCursor reports whether the event comes after the cursor
+func afterCursor(event SyncEvent, cursor SyncCursor) bool {
+	if event.UpdatedAt.After(cursor.UpdatedAt) {
+		return true
+	}
+	return event.UpdatedAt.Equal(cursor.UpdatedAt) &&
+		compareIds(event.Id, cursor.Id) > 0
+}
+
+// compareIds compares numeric ids of arbitrary length
+func compareIds(a string, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
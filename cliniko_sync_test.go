@@ -0,0 +1,92 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+func TestSyncer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	srv := clinikotest.NewServer(clinikotest.WithClock(func() time.Time { return now }))
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Minute)
+		if _, err := srv.Seed("individual_appointments", map[string]any{
+			"starts_at": now.Format(time.RFC3339),
+		}); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+
+	var events []cliniko.SyncEvent
+	sink := cliniko.SyncSinkFunc(func(ctx context.Context, page []cliniko.SyncEvent) error {
+		events = append(events, page...)
+		return nil
+	})
+
+	requests := 0
+	countRequests := func(ctx context.Context, req *http.Request) error {
+		requests++
+		return nil
+	}
+
+	resource, err := cliniko.NewSyncResource("ListIndividualAppointmentsGet")
+	if err != nil {
+		t.Fatalf("NewSyncResource: %v", err)
+	}
+
+	syncer := client.NewSyncer(cliniko.NewMemoryCheckpointStore(), sink, countRequests)
+	syncer.PageSize = 2
+
+	delivered, err := syncer.SyncResource(context.Background(), resource)
+	if err != nil {
+		t.Fatalf("SyncResource: %v", err)
+	}
+	if delivered != 5 || len(events) != 5 {
+		t.Fatalf("delivered %d events, want 5", delivered)
+	}
+	if requests == 0 {
+		t.Errorf("request editors passed to NewSyncer were not applied")
+	}
+
+	// archive one appointment and delete another, only
+	// those two must be delivered by the next run
+	now = now.Add(time.Minute)
+	rsp, err := client.ArchiveIndividualAppointmentPostWithResponse(context.Background(), events[0].Id)
+	if err != nil || rsp.StatusCode() != http.StatusNoContent {
+		t.Fatalf("ArchiveIndividualAppointmentPost: %v %v", rsp.Status(), err)
+	}
+	now = now.Add(time.Minute)
+	deleted, err := client.DeleteIndividualAppointmentDeleteWithResponse(context.Background(), events[1].Id)
+	if err != nil || deleted.StatusCode() != http.StatusNoContent {
+		t.Fatalf("DeleteIndividualAppointmentDelete: %v %v", deleted.Status(), err)
+	}
+
+	events = nil
+	if _, err := syncer.SyncResource(context.Background(), resource); err != nil {
+		t.Fatalf("SyncResource: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events after the changes, want 2", len(events))
+	}
+	if events[0].Type != cliniko.SyncUpsert || !events[0].Archived {
+		t.Errorf("first event = %s archived %v, want an archived upsert", events[0].Type, events[0].Archived)
+	}
+	if events[1].Type != cliniko.SyncDelete {
+		t.Errorf("second event = %s, want %s", events[1].Type, cliniko.SyncDelete)
+	}
+}
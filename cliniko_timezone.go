@@ -0,0 +1,155 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ErrMultipleTimeZones is returned by AccountTimeZone for accounts
+// with time zone support whose businesses are in different time
+// zones, use BusinessTimeZone for those
+var ErrMultipleTimeZones = errors.New("cliniko: businesses are in different time zones")
+
+// LoadTimeZone returns the location of an IANA identifier
+// as sent in the TimeZoneIdentifier fields, e.g.
+// "Australia/Melbourne"
+func LoadTimeZone(identifier *string) (*time.Location, error) {
+	if identifier == nil || *identifier == "" {
+		return nil, errors.New("cliniko: time zone identifier is not set")
+	}
+
+	location, err := time.LoadLocation(*identifier)
+	if err != nil {
+		return nil, fmt.Errorf("cliniko: unknown time zone %q: %w", *identifier, err)
+	}
+	return location, nil
+}
+
+// BusinessTimeZone returns the time zone of a business,
+// appointments of the business are scheduled in it
+func (c *ClinikoClient) BusinessTimeZone(
+	ctx context.Context,
+	id BusinessId,
+	reqEditors ...RequestEditorFn,
+) (
+	*time.Location, error,
+) {
+	business, err := c.GetBusiness(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return LoadTimeZone(business.TimeZoneIdentifier)
+}
+
+// AccountTimeZone returns the time zone of the account. The
+// settings do not contain it, so it is read from the businesses:
+// without time zone support all businesses share the time zone
+// of the account. With time zone support the time zone is only
+// returned if all businesses agree, otherwise the error is
+// ErrMultipleTimeZones.
+func (c *ClinikoClient) AccountTimeZone(
+	ctx context.Context,
+	reqEditors ...RequestEditorFn,
+) (
+	*time.Location, error,
+) {
+	rsp, err := c.GetSettingsGetWithResponse(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"get settings request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+
+	account := rsp.JSON200.Account
+	perZone := account != nil &&
+		account.TimeZoneSupport != nil &&
+		*account.TimeZoneSupport
+
+	perPage := DefaultSyncPageSize
+	if !perZone {
+		perPage = 1
+	}
+
+	var location *time.Location
+	it := c.IterateBusinesses(ctx, &ListBusinessesGetParams{PerPage: &perPage}, reqEditors...)
+	for it.Next() {
+		business := it.Item()
+		next, err := LoadTimeZone(business.TimeZoneIdentifier)
+		if err != nil {
+			return nil, err
+		}
+
+		if !perZone {
+			return next, nil
+		}
+		if location != nil && location.String() != next.String() {
+			return nil, fmt.Errorf("%w: %s and %s", ErrMultipleTimeZones, location, next)
+		}
+		location = next
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, errors.New("cliniko: account has no businesses to read the time zone from")
+	}
+	return location, nil
+}
+
+// LocalTime returns a time of a model, which the API sends in
+// UTC, in the given time zone. It returns false if t is nil.
+func LocalTime(t *time.Time, location *time.Location) (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	return t.In(location), true
+}
+
+// FormatLocal formats a time of a model in the given time
+// zone with layout, e.g. "Mon 2 Jan 15:04 MST". Nil times
+// are formatted as "".
+func FormatLocal(t *time.Time, location *time.Location, layout string) string {
+	local, ok := LocalTime(t, location)
+	if !ok {
+		return ""
+	}
+	return local.Format(layout)
+}
+
+// NewDate returns a date parameter, e.g. From or To, for the
+// given local date. Date parameters are interpreted by the API
+// in the time zone of the account or business.
+func NewDate(year int, month time.Month, day int) openapi_types.Date {
+	return openapi_types.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateIn returns the date parameter of the day t falls on in the
+// given time zone. Passing UTC times straight into a Date uses
+// the UTC day, which is off by one for parts of the local day.
+func DateIn(t time.Time, location *time.Location) openapi_types.Date {
+	year, month, day := t.In(location).Date()
+	return NewDate(year, month, day)
+}
+
+// DayBounds returns the start of a local date and of the
+// following day, e.g. for starts_at filters covering the date
+func DayBounds(date openapi_types.Date, location *time.Location) (time.Time, time.Time) {
+	year, month, day := date.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, location)
+	return start, time.Date(year, month, day+1, 0, 0, 0, 0, location)
+}
@@ -0,0 +1,153 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// TestBusinessTimeZone looks up the time zone of businesses
+func TestBusinessTimeZone(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		business     map[string]any
+		want         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{"melbourne", map[string]any{"business_name": "City", "time_zone_identifier": "Australia/Melbourne"}, "Australia/Melbourne", false, false},
+		{"auckland", map[string]any{"business_name": "Harbour", "time_zone_identifier": "Pacific/Auckland"}, "Pacific/Auckland", false, false},
+		{"unknown time zone", map[string]any{"business_name": "Nowhere", "time_zone_identifier": "Mars/Olympus"}, "", true, false},
+		{"no time zone", map[string]any{"business_name": "Unset"}, "", true, false},
+		{"unknown business", nil, "", true, true},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := "404"
+			if tt.business != nil {
+				seeded, err := srv.Seed("businesses", tt.business)
+				if err != nil {
+					t.Fatalf("Seed: %v", err)
+				}
+				id = seeded
+			}
+
+			location, err := client.BusinessTimeZone(ctx, cliniko.BusinessId(id))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BusinessTimeZone = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, cliniko.ErrNotFound) != tt.wantNotFound {
+				t.Errorf("BusinessTimeZone = %v, want ErrNotFound %v", err, tt.wantNotFound)
+			}
+			if err == nil && location.String() != tt.want {
+				t.Errorf("BusinessTimeZone = %v, want %v", location, tt.want)
+			}
+		})
+	}
+}
+
+// TestAccountTimeZone reads the time zone of the account
+// from its businesses, with and without time zone support
+func TestAccountTimeZone(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeZoneSupport bool
+		zones           []string
+		want            string
+		wantErr         bool
+		wantMultiple    bool
+	}{
+		{"first business", false, []string{"Australia/Perth", "Australia/Sydney"}, "Australia/Perth", false, false},
+		{"businesses agree", true, []string{"Australia/Sydney", "Australia/Sydney"}, "Australia/Sydney", false, false},
+		{"businesses differ", true, []string{"Australia/Perth", "Australia/Sydney"}, "", true, true},
+		{"no businesses", false, nil, "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := clinikotest.NewServer()
+			defer srv.Close()
+			client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+			if err != nil {
+				t.Fatalf("NewClinikoClient: %v", err)
+			}
+			if err := srv.SetSingleton("settings", map[string]any{
+				"account": map[string]any{"country": "Australia", "time_zone_support": tt.timeZoneSupport},
+			}); err != nil {
+				t.Fatalf("SetSingleton: %v", err)
+			}
+			for _, zone := range tt.zones {
+				if _, err := srv.Seed("businesses", map[string]any{
+					"business_name":        zone,
+					"time_zone_identifier": zone,
+				}); err != nil {
+					t.Fatalf("Seed: %v", err)
+				}
+			}
+
+			location, err := client.AccountTimeZone(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AccountTimeZone = %v, want error %v", err, tt.wantErr)
+			}
+			if errors.Is(err, cliniko.ErrMultipleTimeZones) != tt.wantMultiple {
+				t.Errorf("AccountTimeZone = %v, want ErrMultipleTimeZones %v", err, tt.wantMultiple)
+			}
+			if err == nil && location.String() != tt.want {
+				t.Errorf("AccountTimeZone = %v, want %v", location, tt.want)
+			}
+		})
+	}
+}
+
+// TestDayBounds converts local days, the clocks in Melbourne
+// go back on 7 April 2024 which makes the day 25 hours long
+func TestDayBounds(t *testing.T) {
+	melbourne, err := time.LoadLocation("Australia/Melbourne")
+	if err != nil {
+		t.Skipf("time zone database: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		wantDate string
+		wantLen  time.Duration
+	}{
+		{"utc evening is the next local day", time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC), "2024-03-02", 24 * time.Hour},
+		{"end of daylight saving", time.Date(2024, 4, 7, 1, 0, 0, 0, time.UTC), "2024-04-07", 25 * time.Hour},
+		{"start of daylight saving", time.Date(2024, 10, 6, 1, 0, 0, 0, time.UTC), "2024-10-06", 23 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := cliniko.DateIn(tt.t, melbourne)
+			if got := date.String(); got != tt.wantDate {
+				t.Errorf("DateIn = %s, want %s", got, tt.wantDate)
+			}
+
+			start, end := cliniko.DayBounds(date, melbourne)
+			if end.Sub(start) != tt.wantLen {
+				t.Errorf("day is %v long, want %v", end.Sub(start), tt.wantLen)
+			}
+			if tt.t.Before(start) || !tt.t.Before(end) {
+				t.Errorf("%v is not within [%v, %v)", tt.t, start, end)
+			}
+		})
+	}
+}
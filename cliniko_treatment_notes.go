@@ -0,0 +1,635 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+var (
+	// ErrUnknownQuestion is returned for section and
+	// question names the template does not contain
+	ErrUnknownQuestion = errors.New("cliniko: treatment note template has no such question")
+	// ErrAnswerType is returned for answers that do
+	// not match the type of the question
+	ErrAnswerType = errors.New("cliniko: answer does not match the question type")
+	// ErrUnknownOption is returned for values that are not
+	// among the options of checkboxes or radio buttons
+	ErrUnknownOption = errors.New("cliniko: question has no such option")
+	// ErrMissingAnswers is returned by the body methods of a
+	// TreatmentNoteBuilder if required questions are not answered
+	ErrMissingAnswers = errors.New("cliniko: required questions are not answered")
+)
+
+// TreatmentNoteSection is a section of a treatment note template
+type TreatmentNoteSection struct {
+	Name        string
+	Description string
+	Questions   []*TreatmentNoteQuestion
+}
+
+// Question returns the first question of the section with the name
+func (s *TreatmentNoteSection) Question(name string) (*TreatmentNoteQuestion, bool) {
+	for _, question := range s.Questions {
+		if question.Name == name {
+			return question, true
+		}
+	}
+	return nil, false
+}
+
+// TreatmentNoteQuestion is a question of a treatment note template
+// and its answer. The setters check that the answer matches Type.
+type TreatmentNoteQuestion struct {
+	Section string
+	Name    string
+	Type    TreatmentNoteContentSectionsQuestionsType
+	// Options are the values of checkboxes and radio buttons
+	Options []string
+	// OtherEnabled allows a free text answer
+	// besides the options
+	OtherEnabled bool
+	// Required questions have to be answered
+	// in notes that are not drafts
+	Required bool
+
+	// defaultText is the default answer of the template, it
+	// is sent for text questions without an answer but does
+	// not count as one
+	defaultText  string
+	answer       string
+	selected     []bool
+	other        *string
+	bodyChartIds []string
+}
+
+func (q *TreatmentNoteQuestion) String() string {
+	return q.Section + " / " + q.Name
+}
+
+// expect returns ErrAnswerType if the question
+// is not of one of the given types
+func (q *TreatmentNoteQuestion) expect(types ...TreatmentNoteContentSectionsQuestionsType) error {
+	for _, t := range types {
+		if q.Type == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is %s", ErrAnswerType, q, q.Type)
+}
+
+// SetText answers a text or paragraph question
+func (q *TreatmentNoteQuestion) SetText(text string) error {
+	err := q.expect(
+		TreatmentNoteContentSectionsQuestionsTypeText,
+		TreatmentNoteContentSectionsQuestionsTypeParagraph,
+	)
+	if err != nil {
+		return err
+	}
+
+	q.answer = text
+	return nil
+}
+
+// SetDate answers a date question
+func (q *TreatmentNoteQuestion) SetDate(date openapi_types.Date) error {
+	if err := q.expect(TreatmentNoteContentSectionsQuestionsTypeDate); err != nil {
+		return err
+	}
+
+	q.answer = date.Format(openapi_types.DateFormat)
+	return nil
+}
+
+// Select answers checkboxes with any number of options or
+// radio buttons with exactly one, replacing the selection
+func (q *TreatmentNoteQuestion) Select(values ...string) error {
+	err := q.expect(
+		TreatmentNoteContentSectionsQuestionsTypeCheckboxes,
+		TreatmentNoteContentSectionsQuestionsTypeRadiobuttons,
+	)
+	if err != nil {
+		return err
+	}
+
+	radio := q.Type == TreatmentNoteContentSectionsQuestionsTypeRadiobuttons
+	if radio && len(values) != 1 {
+		return fmt.Errorf("%w: %s takes one value, got %d", ErrAnswerType, q, len(values))
+	}
+
+	selected := make([]bool, len(q.Options))
+	for _, value := range values {
+		i := q.option(value)
+		if i < 0 {
+			return fmt.Errorf("%w: %s has no option %q", ErrUnknownOption, q, value)
+		}
+		selected[i] = true
+	}
+
+	q.selected = selected
+	if radio {
+		q.other = nil
+	}
+	return nil
+}
+
+// SetOther answers checkboxes or radio buttons with a free text
+// value, radio buttons lose their selected option. It returns
+// ErrUnknownOption if the question does not allow other values.
+func (q *TreatmentNoteQuestion) SetOther(value string) error {
+	err := q.expect(
+		TreatmentNoteContentSectionsQuestionsTypeCheckboxes,
+		TreatmentNoteContentSectionsQuestionsTypeRadiobuttons,
+	)
+	if err != nil {
+		return err
+	}
+	if !q.OtherEnabled {
+		return fmt.Errorf("%w: %s does not allow other values", ErrUnknownOption, q)
+	}
+
+	q.other = &value
+	if q.Type == TreatmentNoteContentSectionsQuestionsTypeRadiobuttons {
+		q.selected = nil
+	}
+	return nil
+}
+
+// SetBodyCharts answers a body chart question with
+// the ids of the annotated body chart images
+func (q *TreatmentNoteQuestion) SetBodyCharts(ids ...string) error {
+	if err := q.expect(TreatmentNoteContentSectionsQuestionsTypeBodycharts); err != nil {
+		return err
+	}
+
+	q.bodyChartIds = append([]string(nil), ids...)
+	return nil
+}
+
+// Default returns the default answer of a text or
+// paragraph question given by the template
+func (q *TreatmentNoteQuestion) Default() string {
+	return q.defaultText
+}
+
+// Clear removes the answer, text questions
+// fall back to the default of the template
+func (q *TreatmentNoteQuestion) Clear() {
+	q.answer = ""
+	q.selected = nil
+	q.other = nil
+	q.bodyChartIds = nil
+}
+
+// Answered reports whether the question has an answer
+func (q *TreatmentNoteQuestion) Answered() bool {
+	switch q.Type {
+	case TreatmentNoteContentSectionsQuestionsTypeCheckboxes,
+		TreatmentNoteContentSectionsQuestionsTypeRadiobuttons:
+		for _, selected := range q.selected {
+			if selected {
+				return true
+			}
+		}
+		return q.other != nil && *q.other != ""
+	case TreatmentNoteContentSectionsQuestionsTypeBodycharts:
+		return len(q.bodyChartIds) > 0
+	}
+	return q.answer != ""
+}
+
+// option returns the index of an option or -1
+func (q *TreatmentNoteQuestion) option(value string) int {
+	for i, option := range q.Options {
+		if option == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// body returns the question as part of a create request body
+func (q *TreatmentNoteQuestion) body() CreateTreatmentNotePostJSONBodyContentSectionAnswer {
+	questionType := CreateTreatmentNotePostJSONBodyContentSectionsQuestionsType(q.Type)
+	body := CreateTreatmentNotePostJSONBodyContentSectionAnswer{
+		Name: q.Name,
+		Type: &questionType,
+	}
+
+	switch q.Type {
+	case TreatmentNoteContentSectionsQuestionsTypeCheckboxes,
+		TreatmentNoteContentSectionsQuestionsTypeRadiobuttons:
+		answers := make([]CreateTreatmentNotePostJSONBodyContentSectionAnswerAnswer, len(q.Options))
+		for i := range q.Options {
+			selected := i < len(q.selected) && q.selected[i]
+			answers[i] = CreateTreatmentNotePostJSONBodyContentSectionAnswerAnswer{
+				Value:    &q.Options[i],
+				Selected: &selected,
+			}
+		}
+		body.Answers = &answers
+
+		enabled, selected := q.OtherEnabled, q.other != nil
+		body.Other = &CreateTreatmentNotePostJSONBodyContentSectionAnswerOther{
+			Enabled:  &enabled,
+			Selected: &selected,
+			Value:    q.other,
+		}
+	case TreatmentNoteContentSectionsQuestionsTypeBodycharts:
+		ids := append([]string{}, q.bodyChartIds...)
+		body.BodyChartIds = &ids
+	default:
+		switch {
+		case q.answer != "":
+			body.Answer = stringPointer(q.answer)
+		case q.defaultText != "":
+			body.Answer = stringPointer(q.defaultText)
+		}
+	}
+	return body
+}
+
+// TreatmentNoteBuilder builds the request bodies of treatment
+// notes that match a TreatmentNoteTemplate. Sections and questions
+// are looked up by name and answered with type checked setters:
+//
+//	builder, err := client.NewTreatmentNoteBuilder(ctx, templateId)
+//	...
+//	builder.PatientId = patientId
+//	question, err := builder.Question("Subjective", "Presenting complaint")
+//	...
+//	err = question.SetText("Lower back pain")
+//	...
+//	note, err := client.CreateTreatmentNote(ctx, builder)
+//
+// Templates do not mark questions as required, set Required on
+// the questions that have to be answered before a note is final.
+type TreatmentNoteBuilder struct {
+	TemplateId TreatmentNoteTemplateId
+	PatientId  PatientId
+	// BookingId and AttendeeId are optional
+	BookingId  *BookingId
+	AttendeeId *AttendeeId
+	// Title defaults to the name of the template
+	Title string
+	// Draft notes can be saved with required
+	// questions left unanswered
+	Draft bool
+
+	sections []*TreatmentNoteSection
+}
+
+// NewTreatmentNoteBuilder loads a template and
+// returns a TreatmentNoteBuilder for it
+func (c *ClinikoClient) NewTreatmentNoteBuilder(
+	ctx context.Context,
+	templateId TreatmentNoteTemplateId,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNoteBuilder, error,
+) {
+	template, err := c.GetTreatmentNoteTemplate(ctx, templateId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return TreatmentNoteBuilderFromTemplate(*template), nil
+}
+
+// TreatmentNoteBuilderFromTemplate returns a TreatmentNoteBuilder
+// for a template. Default answers of text questions are sent for
+// questions that are not answered, but they do not answer required
+// questions, which are still reported by Missing.
+func TreatmentNoteBuilderFromTemplate(template TreatmentNoteTemplate) *TreatmentNoteBuilder {
+	b := &TreatmentNoteBuilder{}
+	if template.Id != nil {
+		b.TemplateId = TreatmentNoteTemplateId(*template.Id)
+	}
+	if template.Name != nil {
+		b.Title = *template.Name
+	}
+	if template.Content == nil || template.Content.Sections == nil {
+		return b
+	}
+
+	for _, s := range *template.Content.Sections {
+		section := &TreatmentNoteSection{}
+		if s.Name != nil {
+			section.Name = *s.Name
+		}
+		if s.Description != nil {
+			section.Description = *s.Description
+		}
+
+		if s.Questions != nil {
+			for _, q := range *s.Questions {
+				question := &TreatmentNoteQuestion{
+					Section: section.Name,
+					Name:    q.Name,
+				}
+				if q.Type != nil {
+					question.Type = TreatmentNoteContentSectionsQuestionsType(*q.Type)
+				}
+				if q.Answers != nil {
+					for _, answer := range *q.Answers {
+						if answer.Value != nil {
+							question.Options = append(question.Options, *answer.Value)
+						}
+					}
+				}
+				if q.Other != nil && q.Other.Enabled != nil {
+					question.OtherEnabled = *q.Other.Enabled
+				}
+				if q.Answer != nil && question.expect(
+					TreatmentNoteContentSectionsQuestionsTypeText,
+					TreatmentNoteContentSectionsQuestionsTypeParagraph,
+				) == nil {
+					question.defaultText = *q.Answer
+				}
+				section.Questions = append(section.Questions, question)
+			}
+		}
+		b.sections = append(b.sections, section)
+	}
+	return b
+}
+
+// Sections returns the sections of the template in order
+func (b *TreatmentNoteBuilder) Sections() []*TreatmentNoteSection {
+	return b.sections
+}
+
+// Section returns the first section with the name
+func (b *TreatmentNoteBuilder) Section(name string) (*TreatmentNoteSection, bool) {
+	for _, section := range b.sections {
+		if section.Name == name {
+			return section, true
+		}
+	}
+	return nil, false
+}
+
+// Question returns a question by section and question
+// name, or ErrUnknownQuestion if there is none
+func (b *TreatmentNoteBuilder) Question(section string, name string) (*TreatmentNoteQuestion, error) {
+	if s, ok := b.Section(section); ok {
+		if question, ok := s.Question(name); ok {
+			return question, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s / %s", ErrUnknownQuestion, section, name)
+}
+
+// Require marks questions of a section as required
+func (b *TreatmentNoteBuilder) Require(section string, names ...string) error {
+	for _, name := range names {
+		question, err := b.Question(section, name)
+		if err != nil {
+			return err
+		}
+		question.Required = true
+	}
+	return nil
+}
+
+// Missing returns the required questions without an answer
+func (b *TreatmentNoteBuilder) Missing() []*TreatmentNoteQuestion {
+	var missing []*TreatmentNoteQuestion
+	for _, section := range b.sections {
+		for _, question := range section.Questions {
+			if question.Required && !question.Answered() {
+				missing = append(missing, question)
+			}
+		}
+	}
+	return missing
+}
+
+// LoadNote copies the answers of an existing note into the
+// builder, e.g. before changing some of them for an update.
+// Questions that are not in the template or whose type changed
+// are skipped.
+func (b *TreatmentNoteBuilder) LoadNote(note TreatmentNote) {
+	if id, ok := note.PatientId(); ok {
+		b.PatientId = id
+	}
+	if id, ok := note.BookingId(); ok {
+		b.BookingId = &id
+	}
+	if id, ok := note.AttendeeId(); ok {
+		b.AttendeeId = &id
+	}
+	if note.Title != nil {
+		b.Title = *note.Title
+	}
+	if note.Draft != nil {
+		b.Draft = *note.Draft
+	}
+	if note.Content == nil || note.Content.Sections == nil {
+		return
+	}
+
+	for _, s := range *note.Content.Sections {
+		if s.Name == nil || s.Questions == nil {
+			continue
+		}
+		for _, q := range *s.Questions {
+			question, err := b.Question(*s.Name, q.Name)
+			if err != nil || q.Type == nil || *q.Type != question.Type {
+				continue
+			}
+
+			question.Clear()
+			if q.Answer != nil {
+				question.answer = *q.Answer
+			}
+			if q.BodyChartIds != nil {
+				question.bodyChartIds = append([]string(nil), *q.BodyChartIds...)
+			}
+			if q.Answers != nil {
+				question.selected = make([]bool, len(question.Options))
+				for _, answer := range *q.Answers {
+					if answer.Value == nil || answer.Selected == nil || !*answer.Selected {
+						continue
+					}
+					if i := question.option(*answer.Value); i >= 0 {
+						question.selected[i] = true
+					}
+				}
+			}
+			if q.Other != nil && q.Other.Selected != nil && *q.Other.Selected && q.Other.Value != nil {
+				question.other = stringPointer(*q.Other.Value)
+			}
+		}
+	}
+}
+
+// validate returns ErrMissingAnswers for notes that are not
+// drafts and have required questions without an answer
+func (b *TreatmentNoteBuilder) validate() error {
+	if b.Draft {
+		return nil
+	}
+
+	missing := b.Missing()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(missing))
+	for i, question := range missing {
+		names[i] = question.String()
+	}
+	return fmt.Errorf("%w: %s", ErrMissingAnswers, strings.Join(names, ", "))
+}
+
+// content returns the sections and answers of the note
+func (b *TreatmentNoteBuilder) content() *CreateTreatmentNotePostJSONBodyContent {
+	sections := make([]CreateTreatmentNotePostJSONBodyContentSection, len(b.sections))
+	for i, section := range b.sections {
+		questions := make([]CreateTreatmentNotePostJSONBodyContentSectionAnswer, len(section.Questions))
+		for j, question := range section.Questions {
+			questions[j] = question.body()
+		}
+
+		sections[i] = CreateTreatmentNotePostJSONBodyContentSection{
+			Name:        stringPointer(section.Name),
+			Description: stringPointer(section.Description),
+			Questions:   &questions,
+		}
+	}
+	return &CreateTreatmentNotePostJSONBodyContent{Sections: &sections}
+}
+
+// CreateBody returns the request body of CreateTreatmentNotePost.
+// It returns ErrMissingAnswers if required questions of a note
+// that is not a draft are unanswered.
+func (b *TreatmentNoteBuilder) CreateBody() (CreateTreatmentNotePostJSONRequestBody, error) {
+	if b.PatientId == "" {
+		return CreateTreatmentNotePostJSONRequestBody{},
+			errors.New("cliniko: treatment note has no patient")
+	}
+	if err := b.validate(); err != nil {
+		return CreateTreatmentNotePostJSONRequestBody{}, err
+	}
+
+	body := CreateTreatmentNotePostJSONRequestBody{
+		PatientId:               stringPointer(string(b.PatientId)),
+		TreatmentNoteTemplateId: stringPointer(string(b.TemplateId)),
+		Title:                   stringPointer(b.Title),
+		Draft:                   &b.Draft,
+		Content:                 b.content(),
+	}
+	if b.BookingId != nil {
+		body.BookingId = stringPointer(string(*b.BookingId))
+	}
+	if b.AttendeeId != nil {
+		body.AttendeeId = stringPointer(string(*b.AttendeeId))
+	}
+	return body, nil
+}
+
+// UpdateBody returns the request body of UpdateTreatmentNotePatch.
+// It returns ErrMissingAnswers if required questions of a note
+// that is not a draft are unanswered.
+func (b *TreatmentNoteBuilder) UpdateBody() (UpdateTreatmentNotePatchJSONRequestBody, error) {
+	if err := b.validate(); err != nil {
+		return UpdateTreatmentNotePatchJSONRequestBody{}, err
+	}
+
+	body := UpdateTreatmentNotePatchJSONRequestBody{
+		TreatmentNoteTemplateId: stringPointer(string(b.TemplateId)),
+		Title:                   stringPointer(b.Title),
+		Draft:                   &b.Draft,
+	}
+	if b.PatientId != "" {
+		body.PatientId = stringPointer(string(b.PatientId))
+	}
+	if b.BookingId != nil {
+		body.BookingId = stringPointer(string(*b.BookingId))
+	}
+	if b.AttendeeId != nil {
+		body.AttendeeId = stringPointer(string(*b.AttendeeId))
+	}
+
+	// the update body nests anonymous structs of the same
+	// shape as the create body, so the content is copied
+	// through its JSON encoding
+	content, err := json.Marshal(b.content())
+	if err != nil {
+		return UpdateTreatmentNotePatchJSONRequestBody{}, err
+	}
+	if err := json.Unmarshal(content, &body.Content); err != nil {
+		return UpdateTreatmentNotePatchJSONRequestBody{}, err
+	}
+	return body, nil
+}
+
+// CreateTreatmentNote creates the treatment note of a builder.
+// Missing answers are reported without sending a request.
+func (c *ClinikoClient) CreateTreatmentNote(
+	ctx context.Context,
+	builder *TreatmentNoteBuilder,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNote, error,
+) {
+	body, err := builder.CreateBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.CreateTreatmentNotePostWithResponse(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON201 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"create treatment note request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON201, nil
+}
+
+// UpdateTreatmentNote replaces the content of a treatment note
+// with that of a builder. Missing answers are reported without
+// sending a request.
+func (c *ClinikoClient) UpdateTreatmentNote(
+	ctx context.Context,
+	id TreatmentNoteId,
+	builder *TreatmentNoteBuilder,
+	reqEditors ...RequestEditorFn,
+) (
+	*TreatmentNote, error,
+) {
+	body, err := builder.UpdateBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.UpdateTreatmentNotePatchWithResponse(ctx, string(id), body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.JSON200 == nil {
+		return nil,
+			unsuccessfulResponse(
+				"update treatment note request was unsuccessful",
+				rsp.HTTPResponse,
+				rsp.Body,
+			)
+	}
+	return rsp.JSON200, nil
+}
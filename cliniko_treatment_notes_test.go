@@ -0,0 +1,388 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// testTemplate is a template with a question of each type
+const testTemplate = `{
+	"id": "5",
+	"name": "Initial assessment",
+	"content": {"sections": [{
+		"name": "Subjective",
+		"questions": [
+			{"name": "Complaint", "type": "text", "answer": "No complaints"},
+			{"name": "History", "type": "paragraph"},
+			{"name": "Onset", "type": "date"},
+			{"name": "Sports", "type": "checkboxes",
+				"answers": [{"value": "Running"}, {"value": "Tennis"}],
+				"other": {"enabled": true}},
+			{"name": "Side", "type": "radiobuttons",
+				"answers": [{"value": "Left"}, {"value": "Right"}],
+				"other": {"enabled": true}},
+			{"name": "Level", "type": "radiobuttons",
+				"answers": [{"value": "Low"}, {"value": "High"}]},
+			{"name": "Chart", "type": "bodycharts"}
+		]
+	}]}
+}`
+
+func newTestBuilder(t *testing.T) *cliniko.TreatmentNoteBuilder {
+	t.Helper()
+
+	var template cliniko.TreatmentNoteTemplate
+	if err := json.Unmarshal([]byte(testTemplate), &template); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	return cliniko.TreatmentNoteBuilderFromTemplate(template)
+}
+
+func question(t *testing.T, b *cliniko.TreatmentNoteBuilder, name string) *cliniko.TreatmentNoteQuestion {
+	t.Helper()
+
+	q, err := b.Question("Subjective", name)
+	if err != nil {
+		t.Fatalf("Question: %v", err)
+	}
+	return q
+}
+
+// TestTreatmentNoteSetters calls every setter on every question
+// type, only the setters of the matching types succeed
+func TestTreatmentNoteSetters(t *testing.T) {
+	setters := map[string]func(q *cliniko.TreatmentNoteQuestion) error{
+		"SetText": func(q *cliniko.TreatmentNoteQuestion) error { return q.SetText("text") },
+		"SetDate": func(q *cliniko.TreatmentNoteQuestion) error {
+			return q.SetDate(openapi_types.Date{Time: time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)})
+		},
+		"Select": func(q *cliniko.TreatmentNoteQuestion) error {
+			if len(q.Options) == 0 {
+				return q.Select()
+			}
+			return q.Select(q.Options[0])
+		},
+		"SetOther":      func(q *cliniko.TreatmentNoteQuestion) error { return q.SetOther("other") },
+		"SetBodyCharts": func(q *cliniko.TreatmentNoteQuestion) error { return q.SetBodyCharts("12") },
+	}
+
+	tests := []struct {
+		question string
+		accepts  []string
+	}{
+		{"Complaint", []string{"SetText"}},
+		{"History", []string{"SetText"}},
+		{"Onset", []string{"SetDate"}},
+		{"Sports", []string{"Select", "SetOther"}},
+		{"Side", []string{"Select", "SetOther"}},
+		{"Chart", []string{"SetBodyCharts"}},
+	}
+
+	for _, tt := range tests {
+		for name, set := range setters {
+			t.Run(tt.question+"/"+name, func(t *testing.T) {
+				q := question(t, newTestBuilder(t), tt.question)
+				accepted := false
+				for _, accepts := range tt.accepts {
+					accepted = accepted || accepts == name
+				}
+
+				err := set(q)
+				switch {
+				case accepted && err != nil:
+					t.Errorf("%s on %s: %v", name, q.Type, err)
+				case accepted && !q.Answered():
+					t.Errorf("%s on %s did not answer the question", name, q.Type)
+				case !accepted && !errors.Is(err, cliniko.ErrAnswerType):
+					t.Errorf("%s on %s = %v, want ErrAnswerType", name, q.Type, err)
+				}
+			})
+		}
+	}
+}
+
+// TestTreatmentNoteSelect selects options of checkboxes and
+// radio buttons, radio buttons hold one value or other text
+func TestTreatmentNoteSelect(t *testing.T) {
+	tests := []struct {
+		name       string
+		question   string
+		answer     func(q *cliniko.TreatmentNoteQuestion) error
+		wantErr    error
+		wantValues []string
+		wantOther  *string
+	}{
+		{
+			name:       "radio one value",
+			question:   "Side",
+			answer:     func(q *cliniko.TreatmentNoteQuestion) error { return q.Select("Left") },
+			wantValues: []string{"Left"},
+		},
+		{
+			name:     "radio two values",
+			question: "Side",
+			answer:   func(q *cliniko.TreatmentNoteQuestion) error { return q.Select("Left", "Right") },
+			wantErr:  cliniko.ErrAnswerType,
+		},
+		{
+			name:     "radio no value",
+			question: "Side",
+			answer:   func(q *cliniko.TreatmentNoteQuestion) error { return q.Select() },
+			wantErr:  cliniko.ErrAnswerType,
+		},
+		{
+			name:     "unknown option",
+			question: "Side",
+			answer:   func(q *cliniko.TreatmentNoteQuestion) error { return q.Select("Both") },
+			wantErr:  cliniko.ErrUnknownOption,
+		},
+		{
+			name:     "radio other replaces selection",
+			question: "Side",
+			answer: func(q *cliniko.TreatmentNoteQuestion) error {
+				if err := q.Select("Left"); err != nil {
+					return err
+				}
+				return q.SetOther("Both")
+			},
+			wantOther: stringPointer("Both"),
+		},
+		{
+			name:     "radio selection replaces other",
+			question: "Side",
+			answer: func(q *cliniko.TreatmentNoteQuestion) error {
+				if err := q.SetOther("Both"); err != nil {
+					return err
+				}
+				return q.Select("Right")
+			},
+			wantValues: []string{"Right"},
+		},
+		{
+			name:     "radio other not enabled",
+			question: "Level",
+			answer:   func(q *cliniko.TreatmentNoteQuestion) error { return q.SetOther("Medium") },
+			wantErr:  cliniko.ErrUnknownOption,
+		},
+		{
+			name:     "checkboxes keep other",
+			question: "Sports",
+			answer: func(q *cliniko.TreatmentNoteQuestion) error {
+				if err := q.SetOther("Rowing"); err != nil {
+					return err
+				}
+				return q.Select("Running", "Tennis")
+			},
+			wantValues: []string{"Running", "Tennis"},
+			wantOther:  stringPointer("Rowing"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBuilder(t)
+			b.PatientId = "1"
+			err := tt.answer(question(t, b, tt.question))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			body, err := b.CreateBody()
+			if err != nil {
+				t.Fatalf("CreateBody: %v", err)
+			}
+			for _, q := range *(*body.Content.Sections)[0].Questions {
+				if q.Name != tt.question {
+					continue
+				}
+
+				var values []string
+				for _, answer := range *q.Answers {
+					if *answer.Selected {
+						values = append(values, *answer.Value)
+					}
+				}
+				if !equalStrings(values, tt.wantValues) {
+					t.Errorf("selected %q, want %q", values, tt.wantValues)
+				}
+				if *q.Other.Selected != (tt.wantOther != nil) ||
+					(tt.wantOther != nil && *q.Other.Value != *tt.wantOther) {
+					t.Errorf("other = %v %v, want %v", *q.Other.Selected, q.Other.Value, tt.wantOther)
+				}
+			}
+		})
+	}
+}
+
+// TestTreatmentNoteDefault keeps the default text of the template
+// apart from the answer, so that required questions stay missing
+func TestTreatmentNoteDefault(t *testing.T) {
+	tests := []struct {
+		name        string
+		draft       bool
+		answer      string
+		wantMissing bool
+		wantErr     error
+		wantAnswer  string
+	}{
+		{"final with default", false, "", true, cliniko.ErrMissingAnswers, ""},
+		{"draft with default", true, "", true, nil, "No complaints"},
+		{"final with answer", false, "Knee pain", false, nil, "Knee pain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBuilder(t)
+			b.PatientId = "1"
+			b.Draft = tt.draft
+			if err := b.Require("Subjective", "Complaint"); err != nil {
+				t.Fatalf("Require: %v", err)
+			}
+			q := question(t, b, "Complaint")
+			if q.Default() != "No complaints" {
+				t.Errorf("Default() = %q", q.Default())
+			}
+			if tt.answer != "" {
+				if err := q.SetText(tt.answer); err != nil {
+					t.Fatalf("SetText: %v", err)
+				}
+			}
+
+			if missing := len(b.Missing()) > 0; missing != tt.wantMissing {
+				t.Errorf("missing = %v, want %v", missing, tt.wantMissing)
+			}
+			body, err := b.CreateBody()
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("CreateBody error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if answer := (*(*body.Content.Sections)[0].Questions)[0].Answer; answer == nil || *answer != tt.wantAnswer {
+				t.Errorf("answer = %v, want %q", answer, tt.wantAnswer)
+			}
+		})
+	}
+}
+
+// TestTreatmentNoteRoundTrip creates a note, loads it into a new
+// builder and compares the bodies, the update body carries the
+// same content as the create body
+func TestTreatmentNoteRoundTrip(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	b := newTestBuilder(t)
+	b.PatientId = cliniko.PatientId(patientId)
+	for name, answer := range map[string]func(q *cliniko.TreatmentNoteQuestion) error{
+		"History": func(q *cliniko.TreatmentNoteQuestion) error { return q.SetText("Fell\ntwice") },
+		"Onset": func(q *cliniko.TreatmentNoteQuestion) error {
+			return q.SetDate(openapi_types.Date{Time: time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)})
+		},
+		"Sports": func(q *cliniko.TreatmentNoteQuestion) error {
+			if err := q.Select("Tennis"); err != nil {
+				return err
+			}
+			return q.SetOther("Rowing")
+		},
+		"Side":  func(q *cliniko.TreatmentNoteQuestion) error { return q.SetOther("Both") },
+		"Level": func(q *cliniko.TreatmentNoteQuestion) error { return q.Select("High") },
+		"Chart": func(q *cliniko.TreatmentNoteQuestion) error { return q.SetBodyCharts("12", "13") },
+	} {
+		if err := answer(question(t, b, name)); err != nil {
+			t.Fatalf("answer %s: %v", name, err)
+		}
+	}
+
+	ctx := context.Background()
+	note, err := client.CreateTreatmentNote(ctx, b)
+	if err != nil {
+		t.Fatalf("CreateTreatmentNote: %v", err)
+	}
+	note, err = client.GetTreatmentNote(ctx, cliniko.TreatmentNoteId(*note.Id))
+	if err != nil {
+		t.Fatalf("GetTreatmentNote: %v", err)
+	}
+
+	loaded := newTestBuilder(t)
+	loaded.LoadNote(*note)
+	if loaded.PatientId != b.PatientId || loaded.Title != b.Title {
+		t.Errorf("loaded patient %q and title %q, want %q and %q", loaded.PatientId, loaded.Title, b.PatientId, b.Title)
+	}
+
+	created, err := b.CreateBody()
+	if err != nil {
+		t.Fatalf("CreateBody: %v", err)
+	}
+	want := marshal(t, created.Content)
+
+	t.Run("load note", func(t *testing.T) {
+		body, err := loaded.CreateBody()
+		if err != nil {
+			t.Fatalf("CreateBody: %v", err)
+		}
+		if got := marshal(t, body.Content); got != want {
+			t.Errorf("loaded content\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("update body", func(t *testing.T) {
+		body, err := b.UpdateBody()
+		if err != nil {
+			t.Fatalf("UpdateBody: %v", err)
+		}
+		if got := marshal(t, body.Content); got != want {
+			t.Errorf("update content\n%s\nwant\n%s", got, want)
+		}
+		if body.PatientId == nil || *body.PatientId != patientId {
+			t.Errorf("update patient = %v, want %s", body.PatientId, patientId)
+		}
+	})
+}
+
+func marshal(t *testing.T, value any) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return string(data)
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
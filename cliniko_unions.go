@@ -0,0 +1,271 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownVariant is returned by the Value methods of union
+// types whose JSON does not match any of their variants
+var ErrUnknownVariant = errors.New("cliniko: unknown union variant")
+
+// BookingKind is the variant of a Booking
+type BookingKind string
+
+// Variants of a Booking
+const (
+	BookingUnknown               BookingKind = ""
+	BookingIndividualAppointment BookingKind = "individual_appointment"
+	BookingGroupAppointment      BookingKind = "group_appointment"
+	BookingUnavailableBlock      BookingKind = "unavailable_block"
+)
+
+// CommunicationKind is the variant of a Communication
+type CommunicationKind string
+
+// Variants of a Communication
+const (
+	CommunicationUnknown CommunicationKind = ""
+	CommunicationMemo    CommunicationKind = "memo"
+	CommunicationEmail   CommunicationKind = "email"
+	CommunicationSms     CommunicationKind = "sms"
+)
+
+// PatientAttachmentKind is the variant of a PatientAttachment
+type PatientAttachmentKind string
+
+// Variants of a PatientAttachment
+const (
+	PatientAttachmentUnknown           PatientAttachmentKind = ""
+	PatientAttachmentUploaded          PatientAttachmentKind = "uploaded_patient_attachment"
+	PatientAttachmentFullPatientExport PatientAttachmentKind = "full_patient_export"
+)
+
+// unionFields holds the top level fields of a union value
+type unionFields map[string]json.RawMessage
+
+func decodeUnionFields(raw json.RawMessage) unionFields {
+	var fields unionFields
+	_ = json.Unmarshal(raw, &fields)
+	return fields
+}
+
+// has reports whether any of the fields is present, even if null
+func (f unionFields) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := f[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// collection returns the collection links.self points to
+func (f unionFields) collection() string {
+	var links struct {
+		Self string `json:"self"`
+	}
+	if err := json.Unmarshal(f["links"], &links); err != nil || links.Self == "" {
+		return ""
+	}
+
+	collection, _, err := splitLink(links.Self, nil)
+	if err != nil {
+		return ""
+	}
+	return collection
+}
+
+// Kind returns the variant of the booking. It is taken from the
+// collection of links.self and, if that does not tell, from the
+// fields only present in one of the variants.
+func (t Booking) Kind() BookingKind {
+	fields := decodeUnionFields(t.union)
+	if fields == nil {
+		return BookingUnknown
+	}
+
+	switch fields.collection() {
+	case "individual_appointments":
+		return BookingIndividualAppointment
+	case "group_appointments":
+		return BookingGroupAppointment
+	case "unavailable_blocks":
+		return BookingUnavailableBlock
+	}
+
+	switch {
+	case fields.has("max_attendees"):
+		return BookingGroupAppointment
+	case fields.has("patient", "patient_name", "cancelled_at"):
+		return BookingIndividualAppointment
+	case !fields.has("appointment_type"):
+		return BookingUnavailableBlock
+	}
+	return BookingUnknown
+}
+
+// Value decodes the booking into its variant, an
+// IndividualAppointment, GroupAppointment or UnavailableBlock:
+//
+//	switch booking := value.(type) {
+//	case IndividualAppointment:
+//	case GroupAppointment:
+//	case UnavailableBlock:
+//	}
+func (t Booking) Value() (any, error) {
+	switch t.Kind() {
+	case BookingIndividualAppointment:
+		return t.AsIndividualAppointment()
+	case BookingGroupAppointment:
+		return t.AsGroupAppointment()
+	case BookingUnavailableBlock:
+		return t.AsUnavailableBlock()
+	}
+	return nil, fmt.Errorf("%w: booking %s", ErrUnknownVariant, t.union)
+}
+
+// Kind returns the variant of the communication. Memos have
+// category code 12, other communications are told apart by
+// their type code, 1 for SMS and 2 for email. Phone calls and
+// other types can only be recorded as memos.
+func (t Communication) Kind() CommunicationKind {
+	fields := decodeUnionFields(t.union)
+	if fields == nil {
+		return CommunicationUnknown
+	}
+
+	var (
+		categoryCode int
+		category     string
+		typeCode     int
+		typeName     string
+	)
+	_ = json.Unmarshal(fields["category_code"], &categoryCode)
+	_ = json.Unmarshal(fields["category"], &category)
+	_ = json.Unmarshal(fields["type_code"], &typeCode)
+	_ = json.Unmarshal(fields["type"], &typeName)
+
+	switch {
+	case categoryCode == 12 || category == string(MemoCommunicationCategoryMemo):
+		return CommunicationMemo
+	case typeCode == 1 || typeName == string(SmsCommunicationTypeSMS):
+		return CommunicationSms
+	case typeCode == 2 || typeName == string(EmailCommunicationTypeEmail):
+		return CommunicationEmail
+	case typeCode == 3 || typeCode == 4 ||
+		typeName == string(MemoCommunicationTypePhoneCall) ||
+		typeName == string(MemoCommunicationTypeOther):
+		return CommunicationMemo
+	}
+	return CommunicationUnknown
+}
+
+// Value decodes the communication into its variant, a
+// MemoCommunication, EmailCommunication or SmsCommunication
+func (t Communication) Value() (any, error) {
+	switch t.Kind() {
+	case CommunicationMemo:
+		return t.AsMemoCommunication()
+	case CommunicationEmail:
+		return t.AsEmailCommunication()
+	case CommunicationSms:
+		return t.AsSmsCommunication()
+	}
+	return nil, fmt.Errorf("%w: communication %s", ErrUnknownVariant, t.union)
+}
+
+// Kind returns the variant of the attachment. Uploaded
+// attachments can be archived, full patient exports have
+// no archived_at field.
+func (t PatientAttachment) Kind() PatientAttachmentKind {
+	fields := decodeUnionFields(t.union)
+	switch {
+	case fields == nil:
+		return PatientAttachmentUnknown
+	case fields.has("archived_at"):
+		return PatientAttachmentUploaded
+	}
+	return PatientAttachmentFullPatientExport
+}
+
+// Value decodes the attachment into its variant, an
+// UploadedPatientAttachment or FullPatientExport
+func (t PatientAttachment) Value() (any, error) {
+	switch t.Kind() {
+	case PatientAttachmentUploaded:
+		return t.AsUploadedPatientAttachment()
+	case PatientAttachmentFullPatientExport:
+		return t.AsFullPatientExport()
+	}
+	return nil, fmt.Errorf("%w: patient attachment %s", ErrUnknownVariant, t.union)
+}
+
+// unionValue is implemented by Booking, Communication
+// and PatientAttachment
+type unionValue interface {
+	Value() (any, error)
+}
+
+// VariantIterator yields the decoded variants of the unions
+// returned by an Iterator, ready for a type switch:
+//
+//	it := Variants(client.IterateBookings(ctx, &ListBookingsGetParams{}))
+//	for it.Next() {
+//		switch booking := it.Item().(type) {
+//		case IndividualAppointment:
+//		case GroupAppointment:
+//		case UnavailableBlock:
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type VariantIterator[T unionValue] struct {
+	it   *Iterator[T]
+	item any
+	err  error
+}
+
+// Variants returns a VariantIterator over the unions of it
+func Variants[T unionValue](it *Iterator[T]) *VariantIterator[T] {
+	return &VariantIterator[T]{it: it}
+}
+
+// Next advances to the next union and decodes it. It returns
+// false once all unions have been read or an error occurred,
+// including a union that matches none of its variants.
+func (v *VariantIterator[T]) Next() bool {
+	if v.err != nil || !v.it.Next() {
+		return false
+	}
+
+	v.item, v.err = v.it.Item().Value()
+	return v.err == nil
+}
+
+// Item returns the variant of the current union
+func (v *VariantIterator[T]) Item() any {
+	return v.item
+}
+
+// Err returns the first error encountered while iterating
+func (v *VariantIterator[T]) Err() error {
+	if v.err != nil {
+		return v.err
+	}
+	return v.it.Err()
+}
+
+// All drains the iterator and returns every remaining variant
+func (v *VariantIterator[T]) All() ([]any, error) {
+	var items []any
+	for v.Next() {
+		items = append(items, v.Item())
+	}
+	return items, v.Err()
+}
@@ -0,0 +1,176 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+)
+
+func TestBookingKind(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want cliniko.BookingKind
+		// wantType is the type Value decodes to
+		wantType any
+	}{
+		{
+			"individual appointment link",
+			`{"id":"1","links":{"self":"https://api.au1.cliniko.com/v1/individual_appointments/1"}}`,
+			cliniko.BookingIndividualAppointment, cliniko.IndividualAppointment{},
+		},
+		{
+			"group appointment link",
+			`{"id":"2","links":{"self":"https://api.au1.cliniko.com/v1/group_appointments/2"}}`,
+			cliniko.BookingGroupAppointment, cliniko.GroupAppointment{},
+		},
+		{
+			"unavailable block link",
+			`{"id":"3","links":{"self":"https://api.au1.cliniko.com/v1/unavailable_blocks/3"}}`,
+			cliniko.BookingUnavailableBlock, cliniko.UnavailableBlock{},
+		},
+		{
+			"link wins over fields",
+			`{"id":"4","max_attendees":5,"links":{"self":"https://api.au1.cliniko.com/v1/individual_appointments/4"}}`,
+			cliniko.BookingIndividualAppointment, cliniko.IndividualAppointment{},
+		},
+		{
+			"max attendees",
+			`{"id":"5","max_attendees":5,"appointment_type":{}}`,
+			cliniko.BookingGroupAppointment, cliniko.GroupAppointment{},
+		},
+		{
+			"patient",
+			`{"id":"6","patient":{},"appointment_type":{}}`,
+			cliniko.BookingIndividualAppointment, cliniko.IndividualAppointment{},
+		},
+		{
+			"null cancelled at",
+			`{"id":"7","cancelled_at":null,"appointment_type":{}}`,
+			cliniko.BookingIndividualAppointment, cliniko.IndividualAppointment{},
+		},
+		{
+			"no appointment type",
+			`{"id":"8","starts_at":"2024-01-01T09:00:00Z"}`,
+			cliniko.BookingUnavailableBlock, cliniko.UnavailableBlock{},
+		},
+		{
+			"link to other collection",
+			`{"id":"9","appointment_type":{},"links":{"self":"https://api.au1.cliniko.com/v1/patients/9"}}`,
+			cliniko.BookingUnknown, nil,
+		},
+		{"not an object", `[]`, cliniko.BookingUnknown, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var booking cliniko.Booking
+			if err := json.Unmarshal([]byte(tt.json), &booking); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if got := booking.Kind(); got != tt.want {
+				t.Errorf("Kind = %q, want %q", got, tt.want)
+			}
+
+			value, err := booking.Value()
+			if tt.wantType == nil {
+				if !errors.Is(err, cliniko.ErrUnknownVariant) {
+					t.Errorf("Value = %T, %v, want ErrUnknownVariant", value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Value: %v", err)
+			}
+			if reflect.TypeOf(value) != reflect.TypeOf(tt.wantType) {
+				t.Errorf("Value = %T, want %T", value, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestPatientAttachmentKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		want     cliniko.PatientAttachmentKind
+		wantType any
+	}{
+		{
+			"uploaded",
+			`{"id":"1","filename":"notes.pdf","archived_at":"2024-01-01T09:00:00Z"}`,
+			cliniko.PatientAttachmentUploaded, cliniko.UploadedPatientAttachment{},
+		},
+		{
+			"uploaded not archived",
+			`{"id":"2","filename":"notes.pdf","archived_at":null}`,
+			cliniko.PatientAttachmentUploaded, cliniko.UploadedPatientAttachment{},
+		},
+		{
+			"full patient export",
+			`{"id":"3","filename":"export.zip"}`,
+			cliniko.PatientAttachmentFullPatientExport, cliniko.FullPatientExport{},
+		},
+		{"not an object", `"3"`, cliniko.PatientAttachmentUnknown, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attachment cliniko.PatientAttachment
+			if err := json.Unmarshal([]byte(tt.json), &attachment); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			if got := attachment.Kind(); got != tt.want {
+				t.Errorf("Kind = %q, want %q", got, tt.want)
+			}
+
+			value, err := attachment.Value()
+			if tt.wantType == nil {
+				if !errors.Is(err, cliniko.ErrUnknownVariant) {
+					t.Errorf("Value = %T, %v, want ErrUnknownVariant", value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Value: %v", err)
+			}
+			if reflect.TypeOf(value) != reflect.TypeOf(tt.wantType) {
+				t.Errorf("Value = %T, want %T", value, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestCommunicationKind(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want cliniko.CommunicationKind
+	}{
+		{"memo category code", `{"category_code":12,"type_code":3}`, cliniko.CommunicationMemo},
+		{"sms type code", `{"category_code":1,"type_code":1}`, cliniko.CommunicationSms},
+		{"email type code", `{"category_code":1,"type_code":2}`, cliniko.CommunicationEmail},
+		{"phone call", `{"type_code":3}`, cliniko.CommunicationMemo},
+		{"unknown type", `{"type_code":9}`, cliniko.CommunicationUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var communication cliniko.Communication
+			if err := json.Unmarshal([]byte(tt.json), &communication); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got := communication.Kind(); got != tt.want {
+				t.Errorf("Kind = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
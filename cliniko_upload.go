@@ -0,0 +1,322 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxUploadSize is the size limit of attachments
+// when ClinikoClient.MaxUploadSize is not set
+const DefaultMaxUploadSize int64 = 100 << 20
+
+// uploadChunkSize is the size of the chunks
+// copied from the file into the request body
+const uploadChunkSize = 32 << 10
+
+var (
+	// ErrUploadTooLarge is returned for attachments
+	// larger than the upload size limit
+	ErrUploadTooLarge = errors.New("cliniko: attachment exceeds upload size limit")
+	// ErrUploadSizeUnknown is returned for attachments whose size
+	// can not be determined without reading them, S3 requires it
+	// up front. Pass the size with SizedReader.
+	ErrUploadSizeUnknown = errors.New("cliniko: attachment size is unknown")
+)
+
+// UploadProgressFunc is called while an attachment is uploaded
+// with the number of file bytes sent so far and the file size
+type UploadProgressFunc func(sent int64, total int64)
+
+type uploadProgressKey struct{}
+
+// ContextWithUploadProgress reports the progress of uploads
+// made with the returned context, e.g. by CreateAttachment
+func ContextWithUploadProgress(ctx context.Context, progress UploadProgressFunc) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, progress)
+}
+
+func uploadProgress(ctx context.Context) UploadProgressFunc {
+	progress, _ := ctx.Value(uploadProgressKey{}).(UploadProgressFunc)
+	return progress
+}
+
+func (c *ClinikoClient) maxUploadSize() int64 {
+	if c.MaxUploadSize <= 0 {
+		return DefaultMaxUploadSize
+	}
+	return c.MaxUploadSize
+}
+
+// newS3UploadRequest builds the multipart POST to the presigned
+// url. The form fields are encoded up front so that the length
+// of the body is known, the file itself is streamed.
+func (c *ClinikoClient) newS3UploadRequest(
+	ctx context.Context,
+	presignedUrl *PresignedPostGetResponse,
+	filename string,
+	fileContent io.Reader,
+) (
+	*http.Request, error,
+) {
+	if presignedUrl == nil || presignedUrl.JSON200 == nil {
+		return nil, errors.New("cliniko: presigned url response has no content")
+	}
+
+	content, size, err := uploadContent(fileContent, c.maxUploadSize())
+	if err != nil {
+		return nil, err
+	}
+
+	formFields := map[string]string{
+		"acl":                   string(*presignedUrl.JSON200.Fields.Acl),
+		"key":                   *presignedUrl.JSON200.Fields.Key,
+		"policy":                *presignedUrl.JSON200.Fields.Policy,
+		"success_action_status": string(*presignedUrl.JSON200.Fields.SuccessActionStatus),
+		"x-amz-date":            time.Now().UTC().Format("20060102T150405Z"),
+		"x-amz-algorithm":       *presignedUrl.JSON200.Fields.XAmzAlgorithm,
+		"x-amz-credential":      *presignedUrl.JSON200.Fields.XAmzCredential,
+		"x-amz-signature":       *presignedUrl.JSON200.Fields.XAmzSignature,
+	}
+
+	var form bytes.Buffer
+	s3Form := multipart.NewWriter(&form)
+	for key, val := range formFields {
+		if err := s3Form.WriteField(key, val); err != nil {
+			return nil, err
+		}
+	}
+
+	// S3 ignores all fields after the file, so it has to be last
+	if _, err := s3Form.CreateFormFile("file", filename); err != nil {
+		return nil, err
+	}
+
+	head := append([]byte(nil), form.Bytes()...)
+	form.Reset()
+	if err := s3Form.Close(); err != nil {
+		return nil, err
+	}
+	tail := form.Bytes()
+
+	upload := &s3Upload{
+		head:     head,
+		content:  content,
+		size:     size,
+		tail:     tail,
+		progress: uploadProgress(ctx),
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		*presignedUrl.JSON200.Url,
+		upload.body(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = int64(len(head)) + size + int64(len(tail))
+	if seeker, ok := content.(io.Seeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				// the previous body must stop reading the
+				// content before it is rewound
+				upload.stop()
+				if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return upload.body(), nil
+			}
+		}
+	}
+
+	req.Header.Add("Content-Type", s3Form.FormDataContentType())
+	req.Header.Add("Accept", "application/xml")
+
+	return req, nil
+}
+
+// SizedReader returns a reader of size bytes read from r, for
+// uploading content whose size is known but can not be told
+// from the reader, e.g. a network stream
+func SizedReader(r io.Reader, size int64) io.Reader {
+	return &sizedReader{r: io.LimitReader(r, size), left: size}
+}
+
+type sizedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	s.left -= int64(n)
+	return n, err
+}
+
+// Len returns the number of bytes left
+func (s *sizedReader) Len() int {
+	return int(s.left)
+}
+
+// uploadContent returns the content and its size. The size of
+// readers with a Len method and of seekable readers such as
+// *os.File is known up front, other readers are rejected with
+// ErrUploadSizeUnknown rather than read into memory.
+func uploadContent(content io.Reader, limit int64) (io.Reader, int64, error) {
+	size, ok := contentSize(content)
+	if !ok {
+		return nil, 0, fmt.Errorf(
+			"%w: pass a reader with a Len method, an io.Seeker or a SizedReader",
+			ErrUploadSizeUnknown,
+		)
+	}
+
+	if size > limit {
+		return nil, 0, fmt.Errorf("%w: %d bytes, limit is %d", ErrUploadTooLarge, size, limit)
+	}
+	return content, size, nil
+}
+
+// contentSize returns the number of bytes left in r
+func contentSize(r io.Reader) (int64, bool) {
+	switch content := r.(type) {
+	case interface{ Len() int }:
+		return int64(content.Len()), true
+	case io.Seeker:
+		current, err := content.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := content.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		}
+		if _, err := content.Seek(current, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - current, true
+	}
+	return 0, false
+}
+
+// s3Upload is the multipart body of an upload
+// with the file between the encoded form parts
+type s3Upload struct {
+	head     []byte
+	content  io.Reader
+	size     int64
+	tail     []byte
+	progress UploadProgressFunc
+
+	mu      sync.Mutex
+	current *uploadBody
+}
+
+// body returns a reader that streams the upload through a pipe,
+// the copying starts with the first read so that requests that
+// are never sent do not leak a goroutine
+func (u *s3Upload) body() io.ReadCloser {
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	body := &uploadBody{
+		PipeReader: reader,
+		start:      func() { go u.write(writer, done) },
+		done:       done,
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.current = body
+	return body
+}
+
+// stop closes the current body and waits until
+// its goroutine no longer touches the content
+func (u *s3Upload) stop() {
+	u.mu.Lock()
+	body := u.current
+	u.mu.Unlock()
+
+	if body != nil {
+		body.stop()
+	}
+}
+
+// write copies exactly size bytes of the file into
+// the pipe and closes done once it returned
+func (u *s3Upload) write(writer *io.PipeWriter, done chan struct{}) {
+	defer close(done)
+
+	writer.CloseWithError(func() error {
+		if _, err := writer.Write(u.head); err != nil {
+			return err
+		}
+
+		var sent int64
+		chunk := make([]byte, uploadChunkSize)
+		for sent < u.size {
+			if left := u.size - sent; left < int64(len(chunk)) {
+				chunk = chunk[:left]
+			}
+
+			n, err := u.content.Read(chunk)
+			if n > 0 {
+				if _, err := writer.Write(chunk[:n]); err != nil {
+					return err
+				}
+				sent += int64(n)
+				if u.progress != nil {
+					u.progress(sent, u.size)
+				}
+			}
+
+			if err == io.EOF && sent < u.size {
+				return fmt.Errorf(
+					"cliniko: attachment content ended after %d of %d bytes",
+					sent,
+					u.size,
+				)
+			} else if err != nil && err != io.EOF {
+				return err
+			}
+		}
+
+		_, err := writer.Write(u.tail)
+		return err
+	}())
+}
+
+type uploadBody struct {
+	*io.PipeReader
+
+	once  sync.Once
+	start func()
+	done  chan struct{}
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	b.once.Do(b.start)
+	return b.PipeReader.Read(p)
+}
+
+// stop closes the pipe, which fails the next write of the
+// goroutine, and waits for it to return. A body that was
+// never read is marked done without starting it.
+func (b *uploadBody) stop() {
+	_ = b.PipeReader.Close()
+	b.once.Do(func() { close(b.done) })
+	<-b.done
+}
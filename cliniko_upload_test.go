@@ -0,0 +1,112 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// slowReader simulates a file on a slow disk, so that the
+// goroutine of an abandoned body is still inside Read
+type slowReader struct {
+	*bytes.Reader
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(10 * time.Millisecond)
+	return r.Reader.Read(p)
+}
+
+// TestUploadGetBodyRewind rewinds the body while the previous
+// attempt is still streaming, run it with -race
+func TestUploadGetBodyRewind(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 4*uploadChunkSize)
+	var progress []int64
+	ctx := ContextWithUploadProgress(context.Background(), func(sent int64, total int64) {
+		progress = append(progress, sent)
+	})
+
+	c := &ClinikoClient{}
+	req, err := c.newS3UploadRequest(ctx, testPresignedPost(t), "file.txt", slowReader{bytes.NewReader(content)})
+	if err != nil {
+		t.Fatalf("newS3UploadRequest: %v", err)
+	}
+	if req.GetBody == nil {
+		t.Fatalf("GetBody is not set for a seekable file")
+	}
+
+	// stream the first attempt like a transport whose connection
+	// fails midway, the file is still being read when it rewinds
+	go func() { _, _ = io.Copy(io.Discard, req.Body) }()
+	time.Sleep(25 * time.Millisecond)
+	_ = req.Body.Close()
+
+	for attempt := 0; attempt < 3; attempt++ {
+		body, err := req.GetBody()
+		if err != nil {
+			t.Fatalf("GetBody: %v", err)
+		}
+		progress = nil
+
+		sent, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("read attempt %d: %v", attempt, err)
+		}
+		if int64(len(sent)) != req.ContentLength {
+			t.Errorf("attempt %d sent %d bytes, want %d", attempt, len(sent), req.ContentLength)
+		}
+		if !bytes.Contains(sent, content) {
+			t.Errorf("attempt %d does not contain the whole file", attempt)
+		}
+		if len(progress) == 0 || progress[len(progress)-1] != int64(len(content)) {
+			t.Errorf("attempt %d reported progress %v, want it to end at %d", attempt, progress, len(content))
+		}
+	}
+}
+
+type uploadTestKey struct{}
+
+func TestUploadContentSize(t *testing.T) {
+	content := []byte("attachment content")
+	c := &ClinikoClient{}
+	ctx := context.WithValue(context.Background(), uploadTestKey{}, "caller")
+
+	tests := []struct {
+		name    string
+		content io.Reader
+		wantErr error
+	}{
+		{"bytes.Reader", bytes.NewReader(content), nil},
+		{"SizedReader", SizedReader(io.MultiReader(bytes.NewReader(content)), int64(len(content))), nil},
+		{"unknown size", io.MultiReader(bytes.NewReader(content)), ErrUploadSizeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := c.NewUploadFileToS3BucketPostRequestWithContext(ctx, testPresignedPost(t), "file.txt", tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if req.Context().Value(uploadTestKey{}) != "caller" {
+				t.Errorf("request does not carry the context of the caller")
+			}
+
+			sent, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if int64(len(sent)) != req.ContentLength || !bytes.Contains(sent, content) {
+				t.Errorf("sent %d bytes, want %d including the content", len(sent), req.ContentLength)
+			}
+		})
+	}
+}
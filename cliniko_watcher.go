@@ -0,0 +1,347 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultWatchBuffer is the capacity of the Events channel
+const DefaultWatchBuffer = 100
+
+// DefaultWatchReserve is the number of requests of the rate
+// limit budget a Watcher leaves for other callers
+const DefaultWatchReserve = 20
+
+// DefaultWatchRecords is the number of records
+// whose last state a Watcher remembers
+const DefaultWatchRecords = 100000
+
+// WatchEventKind is the kind of change reported by a Watcher
+type WatchEventKind string
+
+const (
+	WatchCreated   WatchEventKind = "created"
+	WatchUpdated   WatchEventKind = "updated"
+	WatchArchived  WatchEventKind = "archived"
+	WatchCancelled WatchEventKind = "cancelled"
+	WatchDeleted   WatchEventKind = "deleted"
+)
+
+// WatchEvent is a change detected by a Watcher
+type WatchEvent struct {
+	Kind      WatchEventKind
+	Resource  string
+	Id        string
+	UpdatedAt time.Time
+	// Record is the record as returned by the API,
+	// use DecodeWatchEvent to get the model type
+	Record json.RawMessage
+}
+
+// DecodeWatchEvent decodes the record of an event
+// into its model type, e.g. IndividualAppointment
+func DecodeWatchEvent[T any](event WatchEvent) (T, error) {
+	var record T
+	err := json.Unmarshal(event.Record, &record)
+	return record, err
+}
+
+// WatchHandlerFor adapts a callback for a single model type
+// to a Watcher handler. Events of other resources are ignored.
+//
+//	watcher.Handler = WatchHandlerFor("individual_appointments",
+//		func(ctx context.Context, kind WatchEventKind, a IndividualAppointment) error {
+//			...
+//		})
+func WatchHandlerFor[T any](
+	resource string,
+	fn func(ctx context.Context, kind WatchEventKind, record T) error,
+) func(ctx context.Context, event WatchEvent) error {
+	return func(ctx context.Context, event WatchEvent) error {
+		if event.Resource != resource {
+			return nil
+		}
+
+		record, err := DecodeWatchEvent[T](event)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, event.Kind, record)
+	}
+}
+
+// RateLimitBudget reports the usage of a request budget,
+// it is implemented by RateLimitedDoer
+type RateLimitBudget interface {
+	Usage() RateLimitUsage
+}
+
+// FindRateLimitBudget returns the first RateLimitBudget in a
+// chain of Doers. Doers wrapping another Doer, e.g. RetryDoer,
+// CacheDoer and InstrumentedDoer, are unwrapped with Unwrap.
+func FindRateLimitBudget(doer HttpRequestDoer) (RateLimitBudget, bool) {
+	for doer != nil {
+		if budget, ok := doer.(RateLimitBudget); ok {
+			return budget, true
+		}
+
+		wrapper, ok := doer.(interface{ Unwrap() HttpRequestDoer })
+		if !ok {
+			break
+		}
+		doer = wrapper.Unwrap()
+	}
+	return nil, false
+}
+
+// watchState is the last known state of a record
+type watchState struct {
+	key       string
+	archived  bool
+	cancelled bool
+}
+
+// Watcher polls resources on an interval and reports created,
+// updated, archived, cancelled and deleted records, emulating
+// webhooks. Events are delivered to Handler if set, otherwise
+// to the Events channel. A slow consumer pauses polling until
+// it caught up, no events are dropped.
+type Watcher struct {
+	// Interval is the time between two polls of all resources
+	Interval time.Duration
+	// Since is the time from which changes are reported,
+	// it defaults to the time Run is called
+	Since time.Time
+	// Handler receives events synchronously instead of the
+	// Events channel, an error stops the watcher
+	Handler func(ctx context.Context, event WatchEvent) error
+	// Budget is consulted before each poll, it defaults to the
+	// RateLimitedDoer found by FindRateLimitBudget in the Doer
+	// of the client. Set it by hand for other Doers.
+	Budget RateLimitBudget
+	// Reserve is the number of requests left to other
+	// callers before the watcher waits for budget, it is
+	// capped at one below the limit of the budget
+	Reserve int
+	// MaxRecords is the number of records whose last state is
+	// remembered. Deleted records are forgotten right away,
+	// beyond that the least recently changed records are
+	// forgotten and classified by their timestamps again.
+	MaxRecords int
+
+	resources []SyncResource
+	syncer    *Syncer
+	events    chan WatchEvent
+
+	mu     sync.Mutex
+	states map[string]*list.Element
+	order  *list.List
+}
+
+// NewWatcher creates a Watcher for the given resources, e.g.
+//
+//	appointments, _ := NewSyncResource("ListIndividualAppointmentsGet")
+//	watcher := client.NewWatcher(time.Minute, appointments)
+func (c *ClinikoClient) NewWatcher(interval time.Duration, resources ...SyncResource) *Watcher {
+	w := &Watcher{
+		Interval:   interval,
+		Reserve:    DefaultWatchReserve,
+		MaxRecords: DefaultWatchRecords,
+		resources:  resources,
+		events:     make(chan WatchEvent, DefaultWatchBuffer),
+		states:     map[string]*list.Element{},
+		order:      list.New(),
+	}
+
+	if budget, ok := FindRateLimitBudget(c.Client.Client); ok {
+		w.Budget = budget
+	}
+
+	w.syncer = c.NewSyncer(NewMemoryCheckpointStore(), SyncSinkFunc(w.deliver))
+	return w
+}
+
+// Events returns the channel events are delivered to when
+// no Handler is set. It is closed once Run returns.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Run polls until ctx is cancelled or the handler fails. On
+// cancellation the current page is finished and nil is returned.
+// Run must only be called once per Watcher.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	if w.Since.IsZero() {
+		w.Since = time.Now().Truncate(time.Second)
+	}
+	for _, resource := range w.resources {
+		cursor := SyncCursor{UpdatedAt: w.Since}
+		if err := w.syncer.store.SaveCursor(ctx, resource.Name, cursor); err != nil {
+			return err
+		}
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	for {
+		for _, resource := range w.resources {
+			if err := w.waitForBudget(ctx); err != nil {
+				return nil
+			}
+
+			if _, err := w.syncer.SyncResource(ctx, resource); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// waitForBudget blocks while less than Reserve
+// requests are left in the rate limit budget
+func (w *Watcher) waitForBudget(ctx context.Context) error {
+	if w.Budget == nil {
+		return ctx.Err()
+	}
+
+	for {
+		usage := w.Budget.Usage()
+		// Remaining never exceeds Limit, a larger
+		// reserve would wait forever
+		reserve := w.Reserve
+		if reserve >= usage.Limit {
+			reserve = usage.Limit - 1
+		}
+		if usage.Remaining > reserve && usage.BlockedUntil.IsZero() {
+			return ctx.Err()
+		}
+
+		wait := time.Until(usage.ResetAt)
+		if usage.BlockedUntil.After(usage.ResetAt) {
+			wait = time.Until(usage.BlockedUntil)
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// deliver classifies the changes of one page and passes them
+// on, blocking until the consumer accepted every event
+func (w *Watcher) deliver(ctx context.Context, events []SyncEvent) error {
+	for _, event := range events {
+		kind, err := w.classify(event)
+		if err != nil {
+			return err
+		}
+
+		watchEvent := WatchEvent{
+			Kind:      kind,
+			Resource:  event.Resource,
+			Id:        event.Id,
+			UpdatedAt: event.UpdatedAt,
+			Record:    event.Record,
+		}
+
+		if w.Handler != nil {
+			if err := w.Handler(ctx, watchEvent); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case w.events <- watchEvent:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// classify compares a changed record against its last known
+// state. Records seen for the first time are classified by
+// their timestamps relative to the watcher's start.
+func (w *Watcher) classify(event SyncEvent) (WatchEventKind, error) {
+	var fields struct {
+		CreatedAt   *time.Time `json:"created_at"`
+		ArchivedAt  *time.Time `json:"archived_at"`
+		CancelledAt *time.Time `json:"cancelled_at"`
+	}
+	if err := json.Unmarshal(event.Record, &fields); err != nil {
+		return "", err
+	}
+
+	current := watchState{
+		key:       event.Resource + "/" + event.Id,
+		archived:  fields.ArchivedAt != nil,
+		cancelled: fields.CancelledAt != nil,
+	}
+	deleted := event.Type == SyncDelete
+
+	previous, known := w.remember(current, deleted)
+	if !known {
+		previous.archived = fields.ArchivedAt != nil && fields.ArchivedAt.Before(w.Since)
+		previous.cancelled = fields.CancelledAt != nil && fields.CancelledAt.Before(w.Since)
+		if fields.CreatedAt != nil && !fields.CreatedAt.Before(w.Since) && !deleted {
+			return WatchCreated, nil
+		}
+	}
+
+	switch {
+	case deleted:
+		return WatchDeleted, nil
+	case current.cancelled && !previous.cancelled:
+		return WatchCancelled, nil
+	case current.archived && !previous.archived:
+		return WatchArchived, nil
+	}
+	return WatchUpdated, nil
+}
+
+// remember stores the state of a record and returns the previous
+// one. Deleted records are forgotten, they do not change again.
+func (w *Watcher) remember(current watchState, deleted bool) (watchState, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var previous watchState
+	element, known := w.states[current.key]
+	if known {
+		previous = *element.Value.(*watchState)
+		w.order.Remove(element)
+		delete(w.states, current.key)
+	}
+	if deleted {
+		return previous, known
+	}
+
+	w.states[current.key] = w.order.PushFront(&current)
+	for w.MaxRecords > 0 && w.order.Len() > w.MaxRecords {
+		oldest := w.order.Back()
+		w.order.Remove(oldest)
+		delete(w.states, oldest.Value.(*watchState).key)
+	}
+	return previous, known
+}
@@ -0,0 +1,91 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package cliniko
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFindRateLimitBudget(t *testing.T) {
+	limited := NewRateLimitedDoer(nil, 10)
+
+	tests := []struct {
+		name string
+		doer HttpRequestDoer
+		want bool
+	}{
+		{"RateLimitedDoer", limited, true},
+		{"RetryDoer", NewRetryDoer(limited, DefaultRetryPolicy()), true},
+		{"CacheDoer", NewCacheDoer(limited, NewLRUCacheStore(0), DefaultCacheTTLs()), true},
+		{"InstrumentedDoer", NewInstrumentedDoer(
+			NewRetryDoer(limited, DefaultRetryPolicy()), &Instrumentation{}), true},
+		{"http.Client", &http.Client{}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		budget, ok := FindRateLimitBudget(tt.doer)
+		if ok != tt.want {
+			t.Errorf("%s: found = %v, want %v", tt.name, ok, tt.want)
+		}
+		if ok && budget != RateLimitBudget(limited) {
+			t.Errorf("%s: found a budget other than the RateLimitedDoer", tt.name)
+		}
+	}
+}
+
+type fixedBudget RateLimitUsage
+
+func (b fixedBudget) Usage() RateLimitUsage {
+	return RateLimitUsage(b)
+}
+
+func TestWatcherReserveAboveLimit(t *testing.T) {
+	w := &Watcher{
+		Reserve: DefaultWatchReserve,
+		Budget:  fixedBudget{Limit: 10, Remaining: 10, ResetAt: time.Now()},
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- w.waitForBudget(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("waitForBudget: %v", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("waitForBudget blocked with a reserve above the limit")
+	}
+}
+
+func TestWatcherForgetsRecords(t *testing.T) {
+	client, err := NewClinikoClient("MS0xLWFiYw-au1", "vendor", "vendor email")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	w := client.NewWatcher(time.Minute)
+	w.MaxRecords = 2
+
+	for _, id := range []string{"1", "2", "3"} {
+		w.remember(watchState{key: "patients/" + id}, false)
+	}
+	if _, known := w.states["patients/1"]; known {
+		t.Errorf("least recently changed record was not forgotten")
+	}
+
+	w.remember(watchState{key: "patients/2"}, true)
+	if _, known := w.states["patients/2"]; known {
+		t.Errorf("deleted record was not forgotten")
+	}
+	if len(w.states) != 1 || w.order.Len() != 1 {
+		t.Errorf("remembering %d records, want 1", len(w.states))
+	}
+}
@@ -0,0 +1,488 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+/*
+The package clinikorender renders treatment notes and patient forms
+into printable HTML, Markdown and PDF documents.
+
+Load a Document from a note or form, its header is read from the
+linked patient, practitioner and appointment:
+
+	doc, err := clinikorender.LoadTreatmentNote(ctx, client, *note)
+	if err != nil {
+		return err
+	}
+
+	err = doc.WritePDF(file)
+
+Signatures of patient forms are fetched with GetSignatureGet and
+embedded into the output. PDFs are written in pure Go with the
+standard Helvetica fonts, so no external service or font file is
+needed. Text outside of the Windows-1252 character set is replaced
+by "?" in PDFs.
+*/
+package clinikorender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Question types rendered by a Document, the union of
+// the types of treatment notes and patient forms
+const (
+	TypeText         = "text"
+	TypeParagraph    = "paragraph"
+	TypeDate         = "date"
+	TypeCheckboxes   = "checkboxes"
+	TypeRadiobuttons = "radiobuttons"
+	TypeBodycharts   = "bodycharts"
+	TypeSignature    = "signature"
+)
+
+// timeLayout formats the times of a Document
+const timeLayout = "Mon 2 Jan 2006 15:04 MST"
+
+// Document is the printable content of a
+// treatment note or patient form
+type Document struct {
+	Title string
+	// Header lists the details of the patient, practitioner
+	// and appointment in the order they are rendered
+	Header   []Field
+	Sections []Section
+	// Location is the time zone times are rendered in, that
+	// of the appointment's business or UTC without one
+	Location *time.Location
+}
+
+// FormatTime formats a time in the Location of the document
+func (d *Document) FormatTime(t time.Time) string {
+	if d.Location == nil {
+		return t.UTC().Format(timeLayout)
+	}
+	return t.In(d.Location).Format(timeLayout)
+}
+
+// Field is a labelled value of a Document header
+type Field struct {
+	Label string
+	Value string
+}
+
+// Section is a section of a Document
+type Section struct {
+	Name        string
+	Description string
+	Questions   []Question
+}
+
+// Question is a question of a Section and its answer
+type Question struct {
+	Name string
+	Type string
+	// Answer is the answer of text, paragraph and date questions
+	Answer string
+	// Choices are the options of checkboxes and radio buttons
+	Choices []Choice
+	// Other is the free text answer given
+	// besides the choices, if any
+	Other *string
+	// BodyChartIds references the annotated body charts
+	BodyChartIds []string
+	// Signature is the signature of a signature question,
+	// nil if the question has not been signed
+	Signature *Signature
+}
+
+// Choice is an option of checkboxes or radio buttons
+type Choice struct {
+	Value    string
+	Selected bool
+}
+
+// Signature is a signature given on a patient form
+type Signature struct {
+	// Image is the image as sent by the API,
+	// usually a data:image/png;base64 URI
+	Image    string
+	SignedAt *time.Time
+}
+
+// Answered reports whether the question has an answer
+func (q Question) Answered() bool {
+	for _, choice := range q.Choices {
+		if choice.Selected {
+			return true
+		}
+	}
+	return q.Answer != "" ||
+		(q.Other != nil && *q.Other != "") ||
+		len(q.BodyChartIds) > 0 ||
+		q.Signature != nil
+}
+
+// LoadTreatmentNote returns the Document of a treatment note
+// with a header read from its patient, practitioner and booking
+func LoadTreatmentNote(
+	ctx context.Context,
+	c *cliniko.ClinikoClient,
+	note cliniko.TreatmentNote,
+	reqEditors ...cliniko.RequestEditorFn,
+) (
+	*Document, error,
+) {
+	doc := &Document{Title: "Treatment note"}
+	if note.Title != nil && *note.Title != "" {
+		doc.Title = *note.Title
+	}
+
+	patient, err := optional(note.ResolvePatient(ctx, c, reqEditors...))
+	if err != nil {
+		return nil, err
+	}
+	practitioner, err := optional(note.ResolvePractitioner(ctx, c, reqEditors...))
+	if err != nil {
+		return nil, err
+	}
+	booking, err := optional(note.ResolveBooking(ctx, c, reqEditors...))
+	if err != nil {
+		return nil, err
+	}
+
+	h := header{}
+	h.patient(patient)
+	h.practitioner(practitioner)
+	if err := h.booking(ctx, c, booking, practitioner == nil, reqEditors); err != nil {
+		return nil, err
+	}
+	if note.AuthorName != nil && *note.AuthorName != "" {
+		h.add("Author", *note.AuthorName)
+	}
+	switch {
+	case note.Draft != nil && *note.Draft:
+		h.add("Status", "Draft")
+	case note.FinalizedAt != nil:
+		h.add("Finalized", h.format(*note.FinalizedAt))
+	}
+	doc.Header, doc.Location = h.fields, h.location
+
+	doc.Sections, err = loadSections(note.Content, nil)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// LoadPatientForm returns the Document of a patient form with a
+// header read from its patient and booking. Signatures are fetched
+// with GetSignatureGet.
+func LoadPatientForm(
+	ctx context.Context,
+	c *cliniko.ClinikoClient,
+	form cliniko.PatientForm,
+	reqEditors ...cliniko.RequestEditorFn,
+) (
+	*Document, error,
+) {
+	doc := &Document{Title: "Patient form"}
+	if form.Name != nil && *form.Name != "" {
+		doc.Title = *form.Name
+	}
+
+	patient, err := optional(form.ResolvePatient(ctx, c, reqEditors...))
+	if err != nil {
+		return nil, err
+	}
+	booking, err := optional(form.ResolveBooking(ctx, c, reqEditors...))
+	if err != nil {
+		return nil, err
+	}
+
+	h := header{}
+	h.patient(patient)
+	if err := h.booking(ctx, c, booking, true, reqEditors); err != nil {
+		return nil, err
+	}
+	if form.CompletedAt != nil {
+		h.add("Completed", h.format(*form.CompletedAt))
+	} else {
+		h.add("Status", "Not completed")
+	}
+	doc.Header, doc.Location = h.fields, h.location
+
+	doc.Sections, err = loadSections(form.Content, func(signatureId string) (*Signature, error) {
+		if form.Id == nil {
+			return nil, nil
+		}
+		return loadSignature(ctx, c, *form.Id, signatureId, reqEditors)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// content holds the fields of the content of treatment notes
+// and patient forms that are rendered. Their generated types
+// differ in the question fields only, so both are read into it.
+type content struct {
+	Sections []struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+		Questions   []struct {
+			Name    string  `json:"name"`
+			Type    *string `json:"type"`
+			Answer  *string `json:"answer"`
+			Answers []struct {
+				Selected *bool   `json:"selected"`
+				Value    *string `json:"value"`
+			} `json:"answers"`
+			Other *struct {
+				Selected *bool   `json:"selected"`
+				Value    *string `json:"value"`
+			} `json:"other"`
+			BodyChartIds []string `json:"body_chart_ids"`
+			SignatureId  *string  `json:"signature_id"`
+		} `json:"questions"`
+	} `json:"sections"`
+}
+
+// loadSections converts the content of a treatment note or
+// patient form into sections, signature loads the signatures
+// of patient forms and is nil for treatment notes
+func loadSections(
+	from any,
+	signature func(signatureId string) (*Signature, error),
+) (
+	[]Section, error,
+) {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var c content
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	var sections []Section
+	for _, s := range c.Sections {
+		section := Section{
+			Name:        deref(s.Name),
+			Description: deref(s.Description),
+		}
+		for _, q := range s.Questions {
+			question := Question{
+				Name:         q.Name,
+				Type:         deref(q.Type),
+				Answer:       deref(q.Answer),
+				BodyChartIds: q.BodyChartIds,
+			}
+			for _, answer := range q.Answers {
+				question.Choices = append(question.Choices, Choice{
+					Value:    deref(answer.Value),
+					Selected: answer.Selected != nil && *answer.Selected,
+				})
+			}
+			if q.Other != nil && q.Other.Selected != nil && *q.Other.Selected {
+				question.Other = q.Other.Value
+			}
+			if q.SignatureId != nil && *q.SignatureId != "" && signature != nil {
+				if question.Signature, err = signature(*q.SignatureId); err != nil {
+					return nil, err
+				}
+			}
+			section.Questions = append(section.Questions, question)
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// loadSignature fetches a signature of a patient form
+func loadSignature(
+	ctx context.Context,
+	c *cliniko.ClinikoClient,
+	formId string,
+	signatureId string,
+	reqEditors []cliniko.RequestEditorFn,
+) (
+	*Signature, error,
+) {
+	rsp, err := cliniko.Check(c.GetSignatureGetWithResponse(ctx, formId, signatureId, reqEditors...))
+	if err != nil {
+		return nil, fmt.Errorf("get signature %s of patient form %s: %w", signatureId, formId, err)
+	}
+
+	if rsp.JSON200 == nil || rsp.JSON200.Image == nil {
+		return nil, nil
+	}
+	return &Signature{
+		Image:    *rsp.JSON200.Image,
+		SignedAt: rsp.JSON200.CreatedAt,
+	}, nil
+}
+
+// header collects the fields of a Document header, times
+// are formatted in the time zone of the appointment's business
+type header struct {
+	fields   []Field
+	location *time.Location
+}
+
+func (h *header) add(label string, value string) {
+	if value != "" {
+		h.fields = append(h.fields, Field{Label: label, Value: value})
+	}
+}
+
+func (h *header) format(t time.Time) string {
+	return (&Document{Location: h.location}).FormatTime(t)
+}
+
+func (h *header) patient(patient *cliniko.Patient) {
+	if patient == nil {
+		return
+	}
+
+	h.add("Patient", join(
+		deref(patient.Title),
+		deref(patient.FirstName),
+		deref(patient.LastName),
+	))
+	if patient.DateOfBirth != nil {
+		h.add("Date of birth", patient.DateOfBirth.Format(openapi_types.DateFormat))
+	}
+}
+
+func (h *header) practitioner(practitioner *cliniko.Practitioner) {
+	if practitioner == nil {
+		return
+	}
+
+	name := deref(practitioner.DisplayName)
+	if name == "" {
+		name = join(
+			deref(practitioner.Title),
+			deref(practitioner.FirstName),
+			deref(practitioner.LastName),
+		)
+	}
+	if designation := deref(practitioner.Designation); designation != "" && name != "" {
+		name += ", " + designation
+	}
+	h.add("Practitioner", name)
+}
+
+// booking adds the appointment details of a booking and, if
+// withPractitioner is set, the practitioner of the appointment
+func (h *header) booking(
+	ctx context.Context,
+	c *cliniko.ClinikoClient,
+	booking *cliniko.Booking,
+	withPractitioner bool,
+	reqEditors []cliniko.RequestEditorFn,
+) error {
+	if booking == nil {
+		return nil
+	}
+
+	var (
+		startsAt        *time.Time
+		appointmentType *cliniko.AppointmentType
+		business        *cliniko.Business
+		practitioner    *cliniko.Practitioner
+	)
+	value, err := booking.Value()
+	if err != nil {
+		return err
+	}
+
+	switch appointment := value.(type) {
+	case cliniko.IndividualAppointment:
+		startsAt = appointment.StartsAt
+		if appointmentType, err = optional(appointment.ResolveAppointmentType(ctx, c, reqEditors...)); err != nil {
+			return err
+		}
+		if business, err = optional(appointment.ResolveBusiness(ctx, c, reqEditors...)); err != nil {
+			return err
+		}
+		if withPractitioner {
+			if practitioner, err = optional(appointment.ResolvePractitioner(ctx, c, reqEditors...)); err != nil {
+				return err
+			}
+		}
+	case cliniko.GroupAppointment:
+		startsAt = appointment.StartsAt
+		if appointmentType, err = optional(appointment.ResolveAppointmentType(ctx, c, reqEditors...)); err != nil {
+			return err
+		}
+		if business, err = optional(appointment.ResolveBusiness(ctx, c, reqEditors...)); err != nil {
+			return err
+		}
+		if withPractitioner {
+			if practitioner, err = optional(appointment.ResolvePractitioner(ctx, c, reqEditors...)); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+
+	if business != nil {
+		if location, err := cliniko.LoadTimeZone(business.TimeZoneIdentifier); err == nil {
+			h.location = location
+		}
+	}
+	h.practitioner(practitioner)
+	if startsAt != nil {
+		h.add("Appointment", h.format(*startsAt))
+	}
+	if appointmentType != nil {
+		h.add("Appointment type", deref(appointmentType.Name))
+	}
+	if business != nil {
+		name := deref(business.BusinessName)
+		if name == "" {
+			name = deref(business.DisplayName)
+		}
+		h.add("Business", name)
+	}
+	return nil
+}
+
+// optional ignores records that no longer exist,
+// e.g. a deleted appointment of an old note
+func optional[T any](record *T, err error) (*T, error) {
+	if errors.Is(err, cliniko.ErrNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// join joins the non empty parts with spaces
+func join(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
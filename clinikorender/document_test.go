@@ -0,0 +1,167 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikorender_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikorender"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+)
+
+// questions returns the content of a note or form with one
+// question of each kind, signature_id is ignored by notes
+func questions() map[string]any {
+	return map[string]any{
+		"sections": []any{
+			map[string]any{
+				"name":        "History",
+				"description": "Previous injuries",
+				"questions": []any{
+					map[string]any{"name": "Complaint", "type": "paragraph", "answer": "Knee pain"},
+					map[string]any{
+						"name": "Sports",
+						"type": "checkboxes",
+						"answers": []any{
+							map[string]any{"value": "Running", "selected": true},
+							map[string]any{"value": "Tennis"},
+						},
+						"other": map[string]any{"enabled": true, "selected": true, "value": "Rowing"},
+					},
+					map[string]any{"name": "Chart", "type": "bodycharts", "body_chart_ids": []any{"12"}},
+					map[string]any{"name": "Consent", "type": "signature", "signature_id": "7"},
+				},
+			},
+		},
+	}
+}
+
+func TestLoad(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+
+	patientId, err := srv.Seed("patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if _, err := srv.Seed("signatures", map[string]any{
+		"id":         "7",
+		"image":      "data:image/png;base64,AAAA",
+		"created_at": "2024-03-01T14:30:00Z",
+	}); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	noteId, err := srv.Seed("treatment_notes", map[string]any{
+		"title":      "Initial assessment",
+		"patient_id": patientId,
+		"content":    questions(),
+	})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	formId, err := srv.Seed("patient_forms", map[string]any{
+		"name":       "Intake",
+		"patient_id": patientId,
+		"content":    questions(),
+	})
+	if err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	other := "Rowing"
+	want := []clinikorender.Question{
+		{Name: "Complaint", Type: clinikorender.TypeParagraph, Answer: "Knee pain"},
+		{
+			Name:    "Sports",
+			Type:    clinikorender.TypeCheckboxes,
+			Choices: []clinikorender.Choice{{Value: "Running", Selected: true}, {Value: "Tennis"}},
+			Other:   &other,
+		},
+		{Name: "Chart", Type: clinikorender.TypeBodycharts, BodyChartIds: []string{"12"}},
+		{Name: "Consent", Type: clinikorender.TypeSignature},
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		load          func() (*clinikorender.Document, error)
+		wantTitle     string
+		wantSignature bool
+		// patient forms have no body charts
+		wantBodyCharts bool
+	}{
+		{
+			name: "treatment note",
+			load: func() (*clinikorender.Document, error) {
+				note, err := client.GetTreatmentNote(ctx, cliniko.TreatmentNoteId(noteId))
+				if err != nil {
+					return nil, err
+				}
+				return clinikorender.LoadTreatmentNote(ctx, client, *note)
+			},
+			wantTitle:      "Initial assessment",
+			wantBodyCharts: true,
+		},
+		{
+			name: "patient form",
+			load: func() (*clinikorender.Document, error) {
+				form, err := client.GetPatientForm(ctx, cliniko.PatientFormId(formId))
+				if err != nil {
+					return nil, err
+				}
+				return clinikorender.LoadPatientForm(ctx, client, *form)
+			},
+			wantTitle:     "Intake",
+			wantSignature: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := tt.load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+
+			if doc.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", doc.Title, tt.wantTitle)
+			}
+			if len(doc.Header) == 0 || doc.Header[0] != (clinikorender.Field{Label: "Patient", Value: "Jane Doe"}) {
+				t.Errorf("header = %+v, want the patient first", doc.Header)
+			}
+			if len(doc.Sections) != 1 {
+				t.Fatalf("%d sections, want 1", len(doc.Sections))
+			}
+			section := doc.Sections[0]
+			if section.Name != "History" || section.Description != "Previous injuries" {
+				t.Errorf("section = %q, %q", section.Name, section.Description)
+			}
+			if len(section.Questions) != len(want) {
+				t.Fatalf("%d questions, want %d", len(section.Questions), len(want))
+			}
+
+			signature := section.Questions[3].Signature
+			if (signature != nil) != tt.wantSignature {
+				t.Errorf("signature = %+v, want loaded %v", signature, tt.wantSignature)
+			}
+			if signature != nil && signature.Image != "data:image/png;base64,AAAA" {
+				t.Errorf("signature image = %q", signature.Image)
+			}
+			section.Questions[3].Signature = nil
+			if !tt.wantBodyCharts && section.Questions[2].BodyChartIds == nil {
+				section.Questions[2].BodyChartIds = want[2].BodyChartIds
+			}
+			if !reflect.DeepEqual(section.Questions, want) {
+				t.Errorf("questions = %+v, want %+v", section.Questions, want)
+			}
+		})
+	}
+}
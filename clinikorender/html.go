@@ -0,0 +1,114 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikorender
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+var htmlTemplate = template.Must(template.New("document").Funcs(template.FuncMap{
+	"image": imageURL,
+	"lines": lines,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 11pt; margin: 2em; color: #222; }
+h1 { font-size: 18pt; margin-bottom: 0.5em; }
+h2 { font-size: 13pt; border-bottom: 1px solid #ccc; margin-top: 1.5em; }
+dl.header { display: grid; grid-template-columns: max-content auto; gap: 0.2em 1em; }
+dl.header dt { font-weight: bold; }
+dl.header dd { margin: 0; }
+.description { color: #555; }
+.question { margin: 0.8em 0; break-inside: avoid; }
+.question h3 { font-size: 11pt; margin: 0 0 0.2em; }
+.question ul { list-style: none; padding-left: 0; margin: 0; }
+.unanswered { color: #888; font-style: italic; }
+.signature img { max-width: 300px; max-height: 120px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Header}}
+<dl class="header">
+{{- range .Header}}
+<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{- end}}
+</dl>
+{{- end}}
+{{- range .Sections}}
+<section>
+{{- if .Name}}
+<h2>{{.Name}}</h2>
+{{- end}}
+{{- if .Description}}
+<p class="description">{{.Description}}</p>
+{{- end}}
+{{- range .Questions}}
+<div class="question">
+<h3>{{.Name}}</h3>
+{{- if not .Answered}}
+<p class="unanswered">Not answered</p>
+{{- else if .Choices}}
+<ul>
+{{- range .Choices}}
+<li>{{if .Selected}}&#9746;{{else}}&#9744;{{end}} {{.Value}}</li>
+{{- end}}
+{{- if .Other}}
+<li>&#9746; Other: {{.Other}}</li>
+{{- end}}
+</ul>
+{{- else if .Signature}}
+<div class="signature">
+{{- with image .Signature.Image}}
+<img src="{{.}}" alt="Signature">
+{{- else}}
+<p>Signed</p>
+{{- end}}
+{{- if .Signature.SignedAt}}
+<p>Signed {{$.FormatTime .Signature.SignedAt}}</p>
+{{- end}}
+</div>
+{{- else if .BodyChartIds}}
+<ul>
+{{- range .BodyChartIds}}
+<li>Body chart {{.}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>{{range $i, $line := lines .Answer}}{{if $i}}<br>{{end}}{{$line}}{{end}}</p>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the document as a standalone HTML page with
+// a print friendly style sheet. Signature images are embedded.
+func (d *Document) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, d)
+}
+
+// imageURL returns a signature image that can be embedded, only
+// data URIs of images are, or "" for others. Remote images are
+// left out so that opening a document does not load anything.
+func imageURL(image string) template.URL {
+	if !strings.HasPrefix(strings.ToLower(image), "data:image/") ||
+		strings.ContainsAny(image, " \t\r\n\"'<>()\\`") {
+		return ""
+	}
+	return template.URL(image)
+}
+
+// lines splits an answer into its lines
+func lines(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,99 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikorender
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownEscaper escapes the characters that
+// Markdown would interpret in answers and names
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`,
+	`[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`,
+	`#`, `\#`, `|`, `\|`,
+)
+
+// WriteMarkdown writes the document as Markdown. Choices are
+// rendered as task lists and signatures as inline images.
+func (d *Document) WriteMarkdown(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, "# %s\n\n", escapeMarkdown(d.Title))
+	for _, field := range d.Header {
+		fmt.Fprintf(b, "**%s:** %s  \n", escapeMarkdown(field.Label), escapeMarkdown(field.Value))
+	}
+	if len(d.Header) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, section := range d.Sections {
+		if section.Name != "" {
+			fmt.Fprintf(b, "## %s\n\n", escapeMarkdown(section.Name))
+		}
+		if section.Description != "" {
+			fmt.Fprintf(b, "_%s_\n\n", escapeMarkdown(section.Description))
+		}
+
+		for _, question := range section.Questions {
+			fmt.Fprintf(b, "### %s\n\n", escapeMarkdown(question.Name))
+			d.writeMarkdownAnswer(b, question)
+			b.WriteString("\n")
+		}
+	}
+	return b.Flush()
+}
+
+func (d *Document) writeMarkdownAnswer(b *bufio.Writer, question Question) {
+	switch {
+	case !question.Answered():
+		b.WriteString("_Not answered_\n")
+	case len(question.Choices) > 0:
+		for _, choice := range question.Choices {
+			mark := " "
+			if choice.Selected {
+				mark = "x"
+			}
+			fmt.Fprintf(b, "- [%s] %s\n", mark, escapeMarkdown(choice.Value))
+		}
+		if question.Other != nil {
+			fmt.Fprintf(b, "- [x] Other: %s\n", escapeMarkdown(*question.Other))
+		}
+	case question.Signature != nil:
+		if image := imageURL(question.Signature.Image); image != "" {
+			fmt.Fprintf(b, "![Signature](<%s>)\n\n", image)
+		}
+		if question.Signature.SignedAt != nil {
+			fmt.Fprintf(b, "Signed %s\n", d.FormatTime(*question.Signature.SignedAt))
+		} else {
+			b.WriteString("Signed\n")
+		}
+	case len(question.BodyChartIds) > 0:
+		for _, id := range question.BodyChartIds {
+			fmt.Fprintf(b, "- Body chart %s\n", escapeMarkdown(id))
+		}
+	default:
+		for _, line := range lines(question.Answer) {
+			fmt.Fprintf(b, "%s  \n", escapeMarkdown(line))
+		}
+	}
+}
+
+// escapeMarkdown escapes text and keeps list markers
+// and numbers at the start of lines from being parsed
+func escapeMarkdown(text string) string {
+	escaped := markdownEscaper.Replace(text)
+	if strings.HasPrefix(escaped, "-") || strings.HasPrefix(escaped, "+") {
+		return `\` + escaped
+	}
+
+	digits := len(escaped) - len(strings.TrimLeft(escaped, "0123456789"))
+	if digits > 0 && (strings.HasPrefix(escaped[digits:], ".") || strings.HasPrefix(escaped[digits:], ")")) {
+		return escaped[:digits] + `\` + escaped[digits:]
+	}
+	return escaped
+}
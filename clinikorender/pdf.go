@@ -0,0 +1,515 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikorender
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Page geometry of PDFs in points, A4 portrait
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	pageMargin   = 50.0
+	contentWidth = pageWidth - 2*pageMargin
+	labelColumn  = 110.0
+	lineSpacing  = 1.35
+)
+
+// Maximum size of signature images in points
+const (
+	signatureWidth  = 220.0
+	signatureHeight = 90.0
+)
+
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+)
+
+// WritePDF writes the document as a PDF using the standard
+// Helvetica fonts. Signature images in PNG or JPEG data URIs
+// are embedded, other signatures are noted as signed.
+func (d *Document) WritePDF(w io.Writer) error {
+	p := &pdfWriter{}
+	p.newPage()
+
+	p.paragraph(d.Title, fontBold, 18, 0)
+	p.space(6)
+	for _, field := range d.Header {
+		p.field(field.Label, field.Value)
+	}
+	p.space(4)
+	p.rule()
+
+	for _, section := range d.Sections {
+		p.space(10)
+		if section.Name != "" {
+			p.ensure(60)
+			p.paragraph(section.Name, fontBold, 14, 0)
+		}
+		if section.Description != "" {
+			p.gray(func() {
+				p.paragraph(section.Description, fontRegular, 10, 0)
+			})
+		}
+
+		for _, question := range section.Questions {
+			p.space(6)
+			p.ensure(40)
+			p.paragraph(question.Name, fontBold, 11, 0)
+			d.writePDFAnswer(p, question)
+		}
+	}
+
+	for i, page := range p.pages {
+		footer := fmt.Sprintf("%s - page %d of %d", d.Title, i+1, len(p.pages))
+		p.page = page
+		p.gray(func() {
+			p.text(pageMargin, pageMargin/2, fontRegular, 8, encodeText(footer))
+		})
+	}
+	return p.writeTo(w, d.Title)
+}
+
+func (d *Document) writePDFAnswer(p *pdfWriter, question Question) {
+	const indent, size = 10.0, 10.0
+
+	switch {
+	case !question.Answered():
+		p.gray(func() {
+			p.paragraph("Not answered", fontRegular, size, indent)
+		})
+	case len(question.Choices) > 0:
+		for _, choice := range question.Choices {
+			mark := "[ ] "
+			if choice.Selected {
+				mark = "[x] "
+			}
+			p.paragraph(mark+choice.Value, fontRegular, size, indent)
+		}
+		if question.Other != nil {
+			p.paragraph("[x] Other: "+*question.Other, fontRegular, size, indent)
+		}
+	case question.Signature != nil:
+		if img, err := decodeImage(question.Signature.Image); err == nil {
+			p.image(img, indent, signatureWidth, signatureHeight)
+		}
+		if question.Signature.SignedAt != nil {
+			p.paragraph("Signed "+d.FormatTime(*question.Signature.SignedAt), fontRegular, size, indent)
+		} else {
+			p.paragraph("Signed", fontRegular, size, indent)
+		}
+	case len(question.BodyChartIds) > 0:
+		for _, id := range question.BodyChartIds {
+			p.paragraph("Body chart "+id, fontRegular, size, indent)
+		}
+	default:
+		for _, line := range lines(question.Answer) {
+			p.paragraph(line, fontRegular, size, indent)
+		}
+	}
+}
+
+// pdfImage is an image XObject with
+// Flate compressed 8 bit RGB samples
+type pdfImage struct {
+	width  int
+	height int
+	data   []byte
+}
+
+// pdfWriter lays out text top to bottom over as many
+// pages as needed and writes them as a PDF document
+type pdfWriter struct {
+	pages  []*bytes.Buffer
+	page   *bytes.Buffer
+	y      float64
+	images []pdfImage
+}
+
+func (p *pdfWriter) newPage() {
+	p.page = &bytes.Buffer{}
+	p.pages = append(p.pages, p.page)
+	p.y = pageHeight - pageMargin
+}
+
+// ensure starts a new page if less than height is left
+func (p *pdfWriter) ensure(height float64) {
+	if p.y-height < pageMargin {
+		p.newPage()
+	}
+}
+
+func (p *pdfWriter) space(height float64) {
+	p.y -= height
+}
+
+func (p *pdfWriter) gray(draw func()) {
+	p.page.WriteString("0.4 g\n")
+	draw()
+	p.page.WriteString("0 g\n")
+}
+
+func (p *pdfWriter) rule() {
+	p.space(4)
+	fmt.Fprintf(p.page, "0.5 w 0.7 G %s %s m %s %s l S 0 G\n",
+		number(pageMargin), number(p.y), number(pageWidth-pageMargin), number(p.y))
+	p.space(4)
+}
+
+// text writes encoded text with its baseline at x, y
+func (p *pdfWriter) text(x float64, y float64, font pdfFont, size float64, text []byte) {
+	fmt.Fprintf(p.page, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, number(size), number(x), number(y), escapePDF(text))
+}
+
+// paragraph writes wrapped text starting at the current line
+func (p *pdfWriter) paragraph(text string, font pdfFont, size float64, indent float64) {
+	for _, line := range wrap(encodeText(text), font, size, contentWidth-indent) {
+		p.ensure(size * lineSpacing)
+		p.y -= size * lineSpacing
+		p.text(pageMargin+indent, p.y, font, size, line)
+	}
+}
+
+// field writes a header field with the value
+// wrapped in the column next to the label
+func (p *pdfWriter) field(label string, value string) {
+	const size = 10.0
+
+	values := wrap(encodeText(value), fontRegular, size, contentWidth-labelColumn)
+	for i, line := range values {
+		p.ensure(size * lineSpacing)
+		p.y -= size * lineSpacing
+		if i == 0 {
+			p.text(pageMargin, p.y, fontBold, size, encodeText(label))
+		}
+		p.text(pageMargin+labelColumn, p.y, fontRegular, size, line)
+	}
+}
+
+// image draws an image scaled down to fit into
+// width and height, keeping its aspect ratio
+func (p *pdfWriter) image(img pdfImage, indent float64, width float64, height float64) {
+	scale := width / float64(img.width)
+	if s := height / float64(img.height); s < scale {
+		scale = s
+	}
+	if scale > 1 {
+		scale = 1
+	}
+	w, h := float64(img.width)*scale, float64(img.height)*scale
+
+	p.ensure(h + 4)
+	p.y -= h + 4
+	fmt.Fprintf(p.page, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		number(w), number(h), number(pageMargin+indent), number(p.y), len(p.images))
+	p.images = append(p.images, img)
+}
+
+// writeTo writes the pages as a PDF with
+// a compressed content stream per page
+func (p *pdfWriter) writeTo(w io.Writer, title string) error {
+	out := &pdfOutput{w: bufio.NewWriter(w)}
+	out.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// objects 1 to 5 are fixed, then come the images
+	// followed by a page and its contents per page
+	const fixedObjects = 5
+	firstPage := fixedObjects + len(p.images) + 1
+
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	out.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	out.object(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	out.object(4, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	out.object(5, fmt.Sprintf("<< /Title (%s) /Producer (clinikorender) >>", escapePDF(encodeText(title))))
+
+	var xObjects strings.Builder
+	for i, img := range p.images {
+		fmt.Fprintf(&xObjects, " /Im%d %d 0 R", i, fixedObjects+1+i)
+		out.stream(fixedObjects+1+i, fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8",
+			img.width, img.height), img.data)
+	}
+
+	for i, page := range p.pages {
+		id := firstPage + 2*i
+		out.object(id, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> /XObject <<%s >> >> /Contents %d 0 R >>",
+			number(pageWidth), number(pageHeight), xObjects.String(), id+1))
+
+		content, err := deflate(page.Bytes())
+		if err != nil {
+			return err
+		}
+		out.stream(id+1, "", content)
+	}
+
+	xref := out.offset
+	out.printf("xref\n0 %d\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		out.printf("%010d 00000 n \n", offset)
+	}
+	out.printf("trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(out.offsets)+1, xref)
+
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// pdfOutput writes objects and records their
+// offsets for the cross reference table
+type pdfOutput struct {
+	w       *bufio.Writer
+	offset  int
+	offsets []int
+	err     error
+}
+
+func (o *pdfOutput) printf(format string, args ...any) {
+	o.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (o *pdfOutput) write(data []byte) {
+	if o.err != nil {
+		return
+	}
+	n, err := o.w.Write(data)
+	o.offset += n
+	o.err = err
+}
+
+// object writes an object, objects have to
+// be written in the order of their ids
+func (o *pdfOutput) object(id int, value string) {
+	o.offsets = append(o.offsets, o.offset)
+	o.printf("%d 0 obj\n%s\nendobj\n", id, value)
+}
+
+// stream writes a Flate compressed stream object
+func (o *pdfOutput) stream(id int, dictionary string, data []byte) {
+	o.offsets = append(o.offsets, o.offset)
+	o.printf("%d 0 obj\n<< %s /Filter /FlateDecode /Length %d >>\nstream\n", id, dictionary, len(data))
+	o.write(data)
+	o.printf("\nendstream\nendobj\n")
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// decodeImage decodes a PNG or JPEG data URI into an
+// image with its transparency composed onto white
+func decodeImage(uri string) (pdfImage, error) {
+	if !strings.HasPrefix(strings.ToLower(uri), "data:image/") {
+		return pdfImage{}, errors.New("clinikorender: signature image is not a data URI")
+	}
+
+	meta, payload, ok := strings.Cut(uri, ",")
+	if !ok {
+		return pdfImage{}, errors.New("clinikorender: invalid data URI")
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(strings.ToLower(meta), ";base64") {
+		data, err = base64.StdEncoding.DecodeString(payload)
+	} else {
+		var unescaped string
+		unescaped, err = url.PathUnescape(payload)
+		data = []byte(unescaped)
+	}
+	if err != nil {
+		return pdfImage{}, fmt.Errorf("clinikorender: invalid data URI: %w", err)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return pdfImage{}, fmt.Errorf("clinikorender: decode signature image: %w", err)
+	}
+
+	bounds := decoded.Bounds()
+	samples := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := decoded.At(x, y).RGBA()
+			samples = append(samples,
+				byte((r+0xffff-a)>>8),
+				byte((g+0xffff-a)>>8),
+				byte((b+0xffff-a)>>8),
+			)
+		}
+	}
+
+	compressed, err := deflate(samples)
+	if err != nil {
+		return pdfImage{}, err
+	}
+	return pdfImage{width: bounds.Dx(), height: bounds.Dy(), data: compressed}, nil
+}
+
+// winAnsi maps the runes of Windows-1252 between 0x80 and 0x9f
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// encodeText encodes text in WinAnsiEncoding, replacing
+// control characters and unsupported runes
+func encodeText(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			encoded = append(encoded, ' ')
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		case r < 0x20 || r == 0x7f:
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// escapePDF escapes encoded text for a PDF string literal
+func escapePDF(text []byte) string {
+	var escaped strings.Builder
+	for _, c := range text {
+		switch c {
+		case '(', ')', '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(c)
+		default:
+			escaped.WriteByte(c)
+		}
+	}
+	return escaped.String()
+}
+
+// wrap breaks encoded text into lines no wider than width,
+// splitting words that are wider than a line on their own
+func wrap(text []byte, font pdfFont, size float64, width float64) [][]byte {
+	words := bytes.Fields(text)
+	if len(words) == 0 {
+		return [][]byte{nil}
+	}
+
+	var (
+		result [][]byte
+		line   []byte
+	)
+	space := textWidth(font, size, []byte{' '})
+	for _, word := range words {
+		for textWidth(font, size, word) > width {
+			if len(line) > 0 {
+				result = append(result, line)
+				line = nil
+			}
+
+			n := 1
+			for n < len(word) && textWidth(font, size, word[:n+1]) <= width {
+				n++
+			}
+			result = append(result, word[:n])
+			word = word[n:]
+		}
+		if len(word) == 0 {
+			continue
+		}
+
+		if len(line) > 0 && textWidth(font, size, line)+space+textWidth(font, size, word) > width {
+			result = append(result, line)
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, word...)
+	}
+	if len(line) > 0 || len(result) == 0 {
+		result = append(result, line)
+	}
+	return result
+}
+
+// textWidth returns the width of encoded text in points
+func textWidth(font pdfFont, size float64, text []byte) float64 {
+	widths := &helveticaWidths
+	if font == fontBold {
+		widths = &helveticaBoldWidths
+	}
+
+	units := 0
+	for _, c := range text {
+		if c >= 0x20 && c < 0x7f {
+			units += widths[c-0x20]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// number formats a coordinate with at most two decimals
+func number(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// Glyph widths of the ASCII characters 0x20 to 0x7e in 1/1000
+// of the font size, from the AFM files of the standard fonts
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
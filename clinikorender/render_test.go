@@ -0,0 +1,256 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikorender
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// signaturePNG is a 2x1 PNG data URI
+const signaturePNG = "data:image/png;base64," +
+	"iVBORw0KGgoAAAANSUhEUgAAAAIAAAABCAIAAAB7QOjdAAAAFElEQVR4nAAHAPj/AgAAAP///wMABg8DAB4SDWkAAAAASUVORK5CYII="
+
+// testDocument returns a document with every question type
+// and text that has to be escaped
+func testDocument() *Document {
+	other := "Swimming & <cycling>"
+	signedAt := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+	return &Document{
+		Title: "Initial <assessment> #1",
+		Header: []Field{
+			{Label: "Patient", Value: "Jane O'Doe"},
+			{Label: "Appointment", Value: "Fri 1 Mar 2024 09:00 AEDT"},
+		},
+		Sections: []Section{
+			{
+				Name:        "History",
+				Description: "Ask about *all* previous injuries",
+				Questions: []Question{
+					{Name: "Complaint", Type: TypeParagraph, Answer: "Pain in the [left] knee\r\n- since March\n1. worse at night"},
+					{Name: "Onset", Type: TypeDate, Answer: "2024-02-20"},
+					{Name: "Notes", Type: TypeText},
+				},
+			},
+			{
+				Name: "Activities",
+				Questions: []Question{
+					{
+						Name: "Sports",
+						Type: TypeCheckboxes,
+						Choices: []Choice{
+							{Value: "Running", Selected: true},
+							{Value: "Tennis_club"},
+						},
+						Other: &other,
+					},
+					{
+						Name:         "Body chart",
+						Type:         TypeBodycharts,
+						BodyChartIds: []string{"12", "13"},
+					},
+					{
+						Name:      "Consent",
+						Type:      TypeSignature,
+						Signature: &Signature{Image: signaturePNG, SignedAt: &signedAt},
+					},
+					{
+						Name:      "Remote signature",
+						Type:      TypeSignature,
+						Signature: &Signature{Image: "https://example.com/signature.png"},
+					},
+				},
+			},
+		},
+		Location: time.UTC,
+	}
+}
+
+// golden compares got to the file in testdata, or
+// overwrites the file if the update flag is set
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run go test -update to create it", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s, run go test -update if intended:\n%s", path, got)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var b bytes.Buffer
+	if err := testDocument().WriteHTML(&b); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	golden(t, "document.html", b.Bytes())
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	if err := testDocument().WriteMarkdown(&b); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	golden(t, "document.md", b.Bytes())
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"*bold* and _italic_", `\*bold\* and \_italic\_`},
+		{"[link](url)", `\[link\](url)`},
+		{"<b>html</b>", `\<b\>html\</b\>`},
+		{"# heading", `\# heading`},
+		{"a | b", `a \| b`},
+		{"`code`", "\\`code\\`"},
+		{`back\slash`, `back\\slash`},
+		{"- item", `\- item`},
+		{"+ item", `\+ item`},
+		{"12. item", `12\. item`},
+		{"3) item", `3\) item`},
+		{"2024-02-20", "2024-02-20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := escapeMarkdown(tt.text); got != tt.want {
+				t.Errorf("escapeMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  bool
+	}{
+		{"png data uri", signaturePNG, true},
+		{"upper case scheme", "DATA:image/jpeg;base64,AAAA", true},
+		{"https", "https://example.com/signature.png", false},
+		{"http", "http://example.com/signature.png", false},
+		{"javascript", "javascript:alert(1)", false},
+		{"html data uri", "data:text/html;base64,PHNjcmlwdD4=", false},
+		{"breaks out of markdown", "data:image/png;base64,AA>)![x](https://example.com", false},
+		{"breaks out of attribute", `data:image/png;base64,AA" onerror="alert(1)`, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageURL(tt.image) != ""; got != tt.want {
+				t.Errorf("imageURL(%q) embedded = %v, want %v", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	pdfStartXref = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	pdfXref      = regexp.MustCompile(`^xref\n0 (\d+)\n0000000000 65535 f \n((?:\d{10} 00000 n \n)*)trailer\n<< /Size (\d+) `)
+	pdfPages     = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+)
+
+// TestWritePDF checks the structure of the PDF: every entry of the
+// cross reference table points to its object, the trailer points
+// to the table and the pages and images are all referenced
+func TestWritePDF(t *testing.T) {
+	long := testDocument()
+	for i := 0; i < 80; i++ {
+		long.Sections[0].Questions = append(long.Sections[0].Questions,
+			Question{Name: "Question " + strconv.Itoa(i), Type: TypeText, Answer: "Answer"})
+	}
+
+	tests := []struct {
+		name       string
+		doc        *Document
+		wantImages int
+		multiPage  bool
+	}{
+		{"document", testDocument(), 1, false},
+		{"empty", &Document{Title: "Empty"}, 0, false},
+		{"several pages", long, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.doc.WritePDF(&b); err != nil {
+				t.Fatalf("WritePDF: %v", err)
+			}
+			pdf := b.Bytes()
+
+			if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+				t.Fatalf("missing PDF header: %q", pdf[:min(len(pdf), 16)])
+			}
+			start := pdfStartXref.FindSubmatch(pdf)
+			if start == nil {
+				t.Fatal("missing startxref or end of file marker")
+			}
+			xrefOffset, _ := strconv.Atoi(string(start[1]))
+			if xrefOffset >= len(pdf) {
+				t.Fatalf("startxref %d is beyond the end of the file", xrefOffset)
+			}
+
+			xref := pdfXref.FindSubmatch(pdf[xrefOffset:])
+			if xref == nil {
+				t.Fatalf("no cross reference table at startxref %d", xrefOffset)
+			}
+			count, _ := strconv.Atoi(string(xref[1]))
+			size, _ := strconv.Atoi(string(xref[3]))
+			entries := bytes.Split(bytes.TrimSuffix(xref[2], []byte("\n")), []byte("\n"))
+			if count != size || len(entries) != count-1 {
+				t.Fatalf("table has %d entries, header %d and trailer /Size %d", len(entries), count, size)
+			}
+
+			for i, entry := range entries {
+				offset, _ := strconv.Atoi(string(entry[:10]))
+				object := strconv.Itoa(i+1) + " 0 obj\n"
+				if !bytes.HasPrefix(pdf[offset:], []byte(object)) {
+					t.Errorf("entry %d points to %q, want %q", i+1, pdf[offset:min(len(pdf), offset+len(object))], object)
+				}
+			}
+
+			pages := pdfPages.FindSubmatch(pdf)
+			if pages == nil {
+				t.Fatal("missing page tree")
+			}
+			pageCount, _ := strconv.Atoi(string(pages[1]))
+			if got := bytes.Count(pdf, []byte("/Type /Page /Parent 2 0 R")); got != pageCount {
+				t.Errorf("%d page objects, page tree counts %d", got, pageCount)
+			}
+			if (pageCount > 1) != tt.multiPage {
+				t.Errorf("%d pages, want several %v", pageCount, tt.multiPage)
+			}
+			if got := bytes.Count(pdf, []byte("/Subtype /Image")); got != tt.wantImages {
+				t.Errorf("%d images, want %d", got, tt.wantImages)
+			}
+			if want := 5 + tt.wantImages + 2*pageCount; size-1 != want {
+				t.Errorf("%d objects, want %d", size-1, want)
+			}
+		})
+	}
+}
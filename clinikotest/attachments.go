@@ -0,0 +1,284 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikotest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxUploadSize limits the size of files uploaded to the fake bucket
+const maxUploadSize = 256 << 20
+
+// object is a file stored in the fake S3 bucket
+type object struct {
+	key         string
+	filename    string
+	contentType string
+	content     []byte
+	modified    time.Time
+}
+
+// Object returns the content of a file uploaded to the fake
+// S3 bucket, the key is the one returned in the S3 response
+func (s *Server) Object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[key]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte(nil), o.content...), true
+}
+
+// SeedAttachment stores a file for the given patient as if it had
+// been uploaded through the presigned S3 flow and returns the id
+// of the resulting patient attachment
+func (s *Server) SeedAttachment(
+	patientId string,
+	filename string,
+	content []byte,
+	description string,
+) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.find("patients", patientId); !ok {
+		return "", fmt.Errorf("patient %s does not exist", patientId)
+	}
+
+	s.nextId++
+	key := fmt.Sprintf("uploads/%d/%s", s.nextId, filename)
+	s.objects[key] = &object{
+		key:         key,
+		filename:    filename,
+		contentType: contentType(filename, content),
+		content:     append([]byte(nil), content...),
+		modified:    s.now(),
+	}
+
+	return s.insertAttachment(patientId, s.objects[key], description), nil
+}
+
+// servePresignedPost returns the fields for a direct upload
+// to the fake S3 bucket
+func (s *Server) servePresignedPost(w http.ResponseWriter, r *http.Request, patientId string) {
+	if _, ok := s.find("patients", patientId); !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	s.nextId++
+	writeJSON(w, http.StatusOK, map[string]any{
+		"url": s.URL + s3Prefix,
+		"fields": map[string]any{
+			"acl":                   "private",
+			"key":                   fmt.Sprintf("uploads/%d/${filename}", s.nextId),
+			"policy":                "clinikotest-policy",
+			"success_action_status": "201",
+			"x-amz-algorithm":       "AWS4-HMAC-SHA256",
+			"x-amz-credential":      "clinikotest/" + s.now().UTC().Format("20060102") + "/s3/aws4_request",
+			"x-amz-signature":       "clinikotest-signature",
+		},
+	})
+}
+
+// serveS3 handles uploads to and downloads from the fake bucket
+func (s *Server) serveS3(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.uploadObject(w, r)
+	case http.MethodGet, http.MethodHead:
+		s.downloadObject(w, r)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "method not allowed")
+	}
+}
+
+func (s *Server) uploadObject(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedPOSTRequest", err.Error())
+		return
+	}
+
+	for _, field := range []string{"key", "policy", "x-amz-signature", "success_action_status"} {
+		if r.FormValue(field) == "" {
+			writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "missing form field "+field)
+			return
+		}
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "missing file")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	key := strings.ReplaceAll(r.FormValue("key"), "${filename}", header.Filename)
+	sum := md5.Sum(content)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	s.mu.Lock()
+	s.objects[key] = &object{
+		key:         key,
+		filename:    header.Filename,
+		contentType: contentType(header.Filename, content),
+		content:     content,
+		modified:    s.now(),
+	}
+	s.mu.Unlock()
+
+	status, err := strconv.Atoi(r.FormValue("success_action_status"))
+	if err != nil {
+		status = http.StatusNoContent
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName  xml.Name `xml:"PostResponse"`
+		Location string   `xml:"Location"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		ETag     string   `xml:"ETag"`
+	}{
+		Location: s.URL + s3Prefix + "/" + key,
+		Bucket:   "clinikotest",
+		Key:      key,
+		ETag:     etag,
+	})
+}
+
+// downloadObject serves a stored file including Range
+// requests. Like S3 it refuses requests that carry an
+// Authorization header in addition to the presigned URL.
+func (s *Server) downloadObject(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument",
+			"Only one auth mechanism allowed")
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, s3Prefix+"/")
+
+	s.mu.Lock()
+	o, ok := s.objects[key]
+	s.mu.Unlock()
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+
+	w.Header().Set("Content-Type", o.contentType)
+	http.ServeContent(w, r, o.filename, o.modified, bytes.NewReader(o.content))
+}
+
+// createAttachment handles POST /patient_attachments which
+// registers a file previously uploaded to the bucket
+func (s *Server) createAttachment(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	invalid := map[string]string{}
+	patientId, _ := body["patient_id"].(string)
+	if _, ok := s.find("patients", patientId); !ok {
+		invalid["patient_id"] = "must exist"
+	}
+
+	uploadUrl, _ := body["upload_url"].(string)
+	key := strings.TrimPrefix(uploadUrl, s.URL+s3Prefix+"/")
+	o, ok := s.objects[key]
+	if !ok {
+		invalid["upload_url"] = "is invalid"
+	}
+
+	if len(invalid) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	description, _ := body["description"].(string)
+	id := s.insertAttachment(patientId, o, description)
+	writeJSON(w, http.StatusCreated, s.collection("patient_attachments").records[id])
+}
+
+// insertAttachment stores the patient attachment record for
+// an uploaded object, the caller must hold s.mu
+func (s *Server) insertAttachment(patientId string, o *object, description string) string {
+	record := map[string]any{
+		"patient_id":           patientId,
+		"filename":             o.filename,
+		"content_type":         o.contentType,
+		"size":                 len(o.content),
+		"description":          description,
+		"processing_completed": true,
+		"processed_at":         s.timestamp(),
+		"pinned_at":            nil,
+	}
+	id := s.insert("patient_attachments", record)
+	s.attachmentKeys[id] = o.key
+	record["content"] = map[string]any{
+		"links": map[string]any{
+			"self": s.BaseURL() + "/patient_attachments/" + id + "/contents",
+		},
+	}
+	return id
+}
+
+// serveAttachmentContents redirects to the stored
+// file the same way the API redirects to S3
+func (s *Server) serveAttachmentContents(w http.ResponseWriter, r *http.Request, name string, id string) {
+	_, ok := s.find(name, id)
+	key, stored := s.attachmentKeys[id]
+	if !ok || !stored || name != "patient_attachments" {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	http.Redirect(w, r, s.URL+s3Prefix+"/"+key+"?X-Amz-Signature=clinikotest", http.StatusFound)
+}
+
+// contentType guesses the content type from the file
+// extension, falling back to sniffing the content
+func contentType(filename string, content []byte) string {
+	if byExtension := mime.TypeByExtension(path.Ext(filename)); byExtension != "" {
+		return byExtension
+	}
+	return http.DetectContentType(content)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{
+		Code:    code,
+		Message: message,
+	})
+}
@@ -0,0 +1,138 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikotest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+// maxAvailabilityDays is the longest from/to range
+// accepted by the availability endpoints
+const maxAvailabilityDays = 7
+
+// AddAvailableTimes offers appointment start times for the given
+// business, practitioner and appointment type. Times that overlap
+// an active booking of the practitioner are not returned.
+func (s *Server) AddAvailableTimes(
+	businessId string,
+	practitionerId string,
+	appointmentTypeId string,
+	startTimes ...time.Time,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := availabilityKey(businessId, practitionerId, appointmentTypeId)
+	for _, start := range startTimes {
+		s.availableTimes[key] = append(s.availableTimes[key], start.UTC())
+	}
+	sort.Slice(s.availableTimes[key], func(i, j int) bool {
+		return s.availableTimes[key][i].Before(s.availableTimes[key][j])
+	})
+}
+
+// serveAvailability handles the available_times and
+// next_available_time endpoints
+func (s *Server) serveAvailability(
+	w http.ResponseWriter,
+	r *http.Request,
+	businessId string,
+	practitionerId string,
+	appointmentTypeId string,
+	action string,
+) {
+	if r.Method != http.MethodGet ||
+		(action != "available_times" && action != "next_available_time") {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	for name, id := range map[string]string{
+		"businesses":        businessId,
+		"practitioners":     practitionerId,
+		"appointment_types": appointmentTypeId,
+	} {
+		if _, ok := s.find(name, id); !ok {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	query := r.URL.Query()
+	location := s.businessLocation(businessId)
+	from, fromErr := time.ParseInLocation("2006-01-02", query.Get("from"), location)
+	to, toErr := time.ParseInLocation("2006-01-02", query.Get("to"), location)
+	invalid := map[string]string{}
+	switch {
+	case fromErr != nil:
+		invalid["from"] = "is not a valid date"
+	case toErr != nil:
+		invalid["to"] = "is not a valid date"
+	case to.Before(from):
+		invalid["to"] = "must be after from"
+	case to.After(from.AddDate(0, 0, maxAvailabilityDays)):
+		invalid["to"] = "cannot be more than 7 days after from"
+	}
+	if len(invalid) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	duration := s.appointmentDuration(appointmentTypeId)
+	bookings := s.activeBookings(practitionerId)
+	end := to.AddDate(0, 0, 1)
+
+	var times []map[string]any
+	for _, start := range s.availableTimes[availabilityKey(businessId, practitionerId, appointmentTypeId)] {
+		if start.Before(from) || !start.Before(end) || booked(bookings, start, start.Add(duration)) {
+			continue
+		}
+		times = append(times, map[string]any{
+			"appointment_start": start.Format(time.RFC3339),
+		})
+	}
+
+	if action == "next_available_time" {
+		body := map[string]any{
+			"links": map[string]any{"self": s.pageURL(r, 1)},
+		}
+		if len(times) > 0 {
+			body["appointment_start"] = times[0]["appointment_start"]
+		}
+		writeJSON(w, http.StatusOK, body)
+		return
+	}
+
+	s.writeList(w, r, "available_times", times)
+}
+
+// booked reports whether any booking overlaps the given range
+func booked(bookings []map[string]any, start time.Time, end time.Time) bool {
+	for _, booking := range bookings {
+		bookingStart, ok1 := parseTime(booking["starts_at"])
+		bookingEnd, ok2 := parseTime(booking["ends_at"])
+		if ok1 && ok2 && bookingStart.Before(end) && start.Before(bookingEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// businessLocation returns the time zone dates are interpreted
+// in, the time_zone_identifier of the business or UTC
+func (s *Server) businessLocation(businessId string) *time.Location {
+	record, _ := s.find("businesses", businessId)
+	identifier, _ := record["time_zone_identifier"].(string)
+	location, err := time.LoadLocation(identifier)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func availabilityKey(businessId string, practitionerId string, appointmentTypeId string) string {
+	return businessId + "/" + practitionerId + "/" + appointmentTypeId
+}
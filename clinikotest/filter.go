@@ -0,0 +1,215 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikotest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// operators supported in q[] filters, longest first
+// so that ">=" is not mistaken for ">"
+var operators = []string{"!=", ">=", "<=", "~~", "=", ">", "<", "~", "*"}
+
+// filter is a single parsed q[] entry, e.g. updated_at:>=2024-01-01
+type filter struct {
+	field    string
+	operator string
+	value    string
+}
+
+type filters []filter
+
+// parseFilters parses the q[] values of a list request
+func parseFilters(values []string) (filters, error) {
+	parsed := make(filters, 0, len(values))
+	for _, value := range values {
+		field, rest, ok := strings.Cut(value, ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid filter %q", value)
+		}
+
+		operator := ""
+		for _, candidate := range operators {
+			if strings.HasPrefix(rest, candidate) {
+				operator = candidate
+				break
+			}
+		}
+		if operator == "" {
+			return nil, fmt.Errorf("invalid operator in filter %q", value)
+		}
+
+		parsed = append(parsed, filter{
+			field:    field,
+			operator: operator,
+			value:    strings.TrimPrefix(rest, operator),
+		})
+	}
+	return parsed, nil
+}
+
+// match reports whether record satisfies all filters. Archived
+// and deleted records are excluded unless a filter on
+// archived_at or deleted_at is given, e.g. archived_at:*
+func (fs filters) match(record map[string]any) bool {
+	for _, field := range []string{"archived_at", "deleted_at"} {
+		if record[field] != nil && !fs.mentions(field) {
+			return false
+		}
+	}
+
+	for _, f := range fs {
+		if !f.match(filterValue(record, f.field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterValue returns the value of field, <name>_id fields
+// are read from the id at the end of the <name> link
+func filterValue(record map[string]any, field string) any {
+	if value, ok := record[field]; ok {
+		return value
+	}
+
+	if name := strings.TrimSuffix(field, "_id"); name != field && record[name] != nil {
+		return linkId(record[name])
+	}
+	return nil
+}
+
+func (fs filters) mentions(field string) bool {
+	for _, f := range fs {
+		if f.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (f filter) match(value any) bool {
+	if f.operator == "*" {
+		return true
+	}
+
+	if values, ok := value.([]any); ok {
+		for _, v := range values {
+			if f.match(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if value == nil {
+		return f.operator == "!=" && f.value != ""
+	}
+
+	text := fmt.Sprint(value)
+	switch f.operator {
+	case "~":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(f.value))
+	case "~~":
+		return wildcardMatch(strings.ToLower(text), strings.ToLower(f.value))
+	}
+
+	cmp := compareValues(value, f.value)
+	switch f.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// compareValues compares two values as numbers, times
+// or strings, whichever both of them can be parsed as
+func compareValues(a any, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		}
+		return 1
+	}
+
+	aText, bText := fmt.Sprint(a), fmt.Sprint(b)
+
+	aNumber, aErr := strconv.ParseFloat(aText, 64)
+	bNumber, bErr := strconv.ParseFloat(bText, 64)
+	if aErr == nil && bErr == nil {
+		return compareOrdered(aNumber, bNumber)
+	}
+
+	aTime, aOk := parseFilterTime(aText)
+	bTime, bOk := parseFilterTime(bText)
+	if aOk && bOk {
+		switch {
+		case aTime.Before(bTime):
+			return -1
+		case aTime.After(bTime):
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(aText, bText)
+}
+
+func compareOrdered(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func parseFilterTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// wildcardMatch matches text against a pattern in
+// which % matches any sequence of characters
+func wildcardMatch(text string, pattern string) bool {
+	parts := strings.Split(pattern, "%")
+	if len(parts) == 1 {
+		return text == pattern
+	}
+
+	if !strings.HasPrefix(text, parts[0]) {
+		return false
+	}
+	text = text[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(text, part)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(part):]
+	}
+	return strings.HasSuffix(text, parts[len(parts)-1])
+}
@@ -0,0 +1,454 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+/*
+The package clinikotest provides an in-memory fake of the Cliniko
+API for tests of code built on the cliniko package.
+
+Start a server and point a ClinikoClient at it:
+
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	client, err := srv.NewClinikoClient("vendor", "vendor email")
+
+The server keeps every resource in memory and supports the create,
+read, update, archive, cancel and delete operations of cliniko.json,
+pagination links, q[] filtering, sorting and the presigned S3 upload
+flow of attachments. Records can be seeded up front:
+
+	patientId, err := srv.Seed("patients", map[string]any{
+		"first_name": "Jane",
+		"last_name":  "Doe",
+	})
+
+Requests without the expected API key are answered with 401,
+unknown records with 404, invalid bodies with 422 and, when a rate
+limit has been configured, excess requests with 429.
+*/
+package clinikotest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+)
+
+// DefaultAPIKey is the API key accepted by a Server
+// unless WithAPIKey is given
+const DefaultAPIKey = "clinikotest-au1"
+
+// apiPrefix is the path under which the API is served
+const apiPrefix = "/v1"
+
+// s3Prefix is the path under which the fake S3 bucket is served
+const s3Prefix = "/s3"
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithAPIKey sets the API key that requests must authenticate with
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithRateLimit answers requests beyond requestsPerMinute
+// within one minute with 429 Too Many Requests
+func WithRateLimit(requestsPerMinute int) Option {
+	return func(s *Server) {
+		s.rateLimit = requestsPerMinute
+	}
+}
+
+// WithClock replaces time.Now as the source of created_at,
+// updated_at, archived_at and the rate limit window
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithRequiredFields overrides the fields that must be present
+// when creating a record of the given collection
+func WithRequiredFields(collection string, fields ...string) Option {
+	return func(s *Server) {
+		s.required[collection] = fields
+	}
+}
+
+// Server is an in-memory fake of the Cliniko API
+type Server struct {
+	*httptest.Server
+
+	apiKey    string
+	rateLimit int
+	now       func() time.Time
+	required  map[string][]string
+
+	mu             sync.Mutex
+	nextId         int64
+	collections    map[string]*collection
+	singletons     map[string]map[string]any
+	objects        map[string]*object
+	attachmentKeys map[string]string
+	availableTimes map[string][]time.Time
+	requests       []time.Time
+}
+
+// NewServer starts a new Server, it must be closed
+// by the caller once done
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:         DefaultAPIKey,
+		now:            time.Now,
+		required:       defaultRequiredFields(),
+		collections:    map[string]*collection{},
+		singletons:     map[string]map[string]any{},
+		objects:        map[string]*object{},
+		attachmentKeys: map[string]string{},
+		availableTimes: map[string][]time.Time{},
+	}
+
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.singletons["settings"] = defaultSettings()
+	s.singletons["settings/public"] = defaultSettings()
+	s.singletons["user"] = map[string]any{
+		"id":         "1",
+		"first_name": "Test",
+		"last_name":  "User",
+		"links":      map[string]any{"self": s.BaseURL() + "/users/1"},
+	}
+	return s
+}
+
+// BaseURL returns the URL the API is served under, use it
+// in place of https://api.au1.cliniko.com/v1
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// APIKey returns the API key accepted by the server
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// NewClinikoClient creates a ClinikoClient that
+// authenticates against and talks to the server
+func (s *Server) NewClinikoClient(
+	vendor string,
+	vendorEmail string,
+	opts ...cliniko.ClinikoClientOption,
+) (
+	*cliniko.ClinikoClient, error,
+) {
+	return cliniko.NewClinikoClientWithOptions(
+		s.apiKey,
+		vendor,
+		vendorEmail,
+		append([]cliniko.ClinikoClientOption{cliniko.WithBaseURL(s.BaseURL())}, opts...)...,
+	)
+}
+
+// SetSingleton replaces the body returned for a resource
+// without an id, i.e. "settings", "settings/public" or "user"
+func (s *Server) SetSingleton(path string, value any) error {
+	record, err := toRecord(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.singletons[path] = record
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, s3Prefix+"/") || r.URL.Path == s3Prefix {
+		s.serveS3(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix+"/") {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if !s.authorized(r) {
+		writeMessage(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if retryAfter, ok := s.throttle(); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeMessage(w, http.StatusTooManyRequests, "Too Many Requests")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r, segments)
+}
+
+// authorized checks the basic auth header, which the
+// cliniko package sends as "Basic: <credentials>"
+func (s *Server) authorized(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Basic") {
+		return false
+	}
+
+	encoded := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(header, "Basic"), ":"))
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
+	}
+
+	username := strings.SplitN(string(decoded), ":", 2)[0]
+	return username == s.apiKey
+}
+
+// throttle records the request and reports whether it is
+// within the rate limit, if not the seconds to wait are returned
+func (s *Server) throttle() (int, bool) {
+	if s.rateLimit <= 0 {
+		return 0, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	cutoff := now.Add(-time.Minute)
+	i := 0
+	for i < len(s.requests) && !s.requests[i].After(cutoff) {
+		i++
+	}
+	s.requests = s.requests[i:]
+
+	if len(s.requests) >= s.rateLimit {
+		wait := s.requests[0].Add(time.Minute).Sub(now)
+		return int((wait + time.Second - 1) / time.Second), false
+	}
+
+	s.requests = append(s.requests, now)
+	return 0, true
+}
+
+// route dispatches the request to the handler for its path,
+// the caller must hold s.mu
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	path := strings.Join(segments, "/")
+	if _, ok := s.singletons[path]; ok {
+		if r.Method != http.MethodGet {
+			writeMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.singletons[path])
+		return
+	}
+
+	switch len(segments) {
+	case 1:
+		s.serveCollection(w, r, segments[0])
+	case 2:
+		switch {
+		case segments[0] == "practitioners" && segments[1] == "inactive":
+			s.serveList(w, r, segments[0], func(record map[string]any) bool {
+				return record["active"] == false
+			})
+		case segments[0] == "patient_cases" && segments[1] == "active":
+			s.serveList(w, r, segments[0], func(record map[string]any) bool {
+				return record["closed"] != true
+			})
+		default:
+			s.serveRecord(w, r, segments[0], segments[1])
+		}
+	case 3:
+		s.serveNested(w, r, segments[0], segments[1], segments[2])
+	case 4:
+		switch {
+		case segments[0] == "patient_forms" && segments[2] == "signatures":
+			s.serveGet(w, r, "signatures", segments[3])
+		case segments[2] == "practitioners" && segments[3] == "inactive":
+			s.serveList(w, r, "practitioners", func(record map[string]any) bool {
+				return record["active"] == false &&
+					s.belongsTo(record, segments[0], segments[1])
+			})
+		default:
+			writeMessage(w, http.StatusNotFound, "Not Found")
+		}
+	case 7:
+		if segments[0] == "businesses" &&
+			segments[2] == "practitioners" &&
+			segments[4] == "appointment_types" {
+			s.serveAvailability(w, r, segments[1], segments[3], segments[5], segments[6])
+			return
+		}
+		writeMessage(w, http.StatusNotFound, "Not Found")
+	default:
+		writeMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, name string) {
+	switch r.Method {
+	case http.MethodGet:
+		if name == "bookings" {
+			s.serveBookings(w, r, nil)
+			return
+		}
+		s.serveList(w, r, name, nil)
+	case http.MethodPost:
+		if name == "patient_attachments" {
+			s.createAttachment(w, r)
+			return
+		}
+		s.serveCreate(w, r, name)
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) serveRecord(w http.ResponseWriter, r *http.Request, name string, id string) {
+	switch r.Method {
+	case http.MethodGet:
+		if name == "bookings" {
+			s.serveBooking(w, r, id)
+			return
+		}
+		s.serveGet(w, r, name, id)
+	case http.MethodPatch, http.MethodPut:
+		s.serveUpdate(w, r, name, id)
+	case http.MethodDelete:
+		record, ok := s.find(name, id)
+		if !ok {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		now := s.timestamp()
+		record["deleted_at"] = now
+		record["updated_at"] = now
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) serveNested(w http.ResponseWriter, r *http.Request, parent string, id string, action string) {
+	switch {
+	case action == "archive" && r.Method == http.MethodPost:
+		s.serveArchive(w, parent, id, true)
+	case action == "unarchive" && r.Method == http.MethodPost:
+		s.serveArchive(w, parent, id, false)
+	case action == "cancel" && r.Method == http.MethodPatch:
+		s.serveCancel(w, r, parent, id)
+	case action == "conflicts" && r.Method == http.MethodGet:
+		s.serveConflicts(w, parent, id)
+	case action == "contents" && r.Method == http.MethodGet:
+		s.serveAttachmentContents(w, r, parent, id)
+	case action == "attachment_presigned_post" && r.Method == http.MethodGet:
+		s.servePresignedPost(w, r, id)
+	case action == "referral_source":
+		s.serveReferralSource(w, r, id)
+	case action == "bookings" && r.Method == http.MethodGet:
+		s.serveBookings(w, r, func(record map[string]any) bool {
+			return s.belongsTo(record, parent, id)
+		})
+	case r.Method == http.MethodGet:
+		if _, ok := s.find(parent, id); !ok {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		s.serveList(w, r, action, func(record map[string]any) bool {
+			return s.belongsTo(record, parent, id)
+		})
+	default:
+		writeMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// timestamp returns the current time as stored in records
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+func defaultRequiredFields() map[string][]string {
+	return map[string][]string{
+		"patients":                 {"first_name", "last_name"},
+		"individual_appointments":  {"patient_id", "practitioner_id", "appointment_type_id", "business_id", "starts_at"},
+		"group_appointments":       {"practitioner_id", "appointment_type_id", "business_id", "starts_at"},
+		"unavailable_blocks":       {"practitioner_id", "business_id", "starts_at", "ends_at"},
+		"attendees":                {"booking_id", "patient_id"},
+		"appointment_types":        {"name", "duration_in_minutes"},
+		"billable_items":           {"name", "item_type"},
+		"businesses":               {"business_name"},
+		"contacts":                 {"first_name", "last_name"},
+		"medical_alerts":           {"name", "patient_id"},
+		"patient_cases":            {"name", "patient_id"},
+		"treatment_notes":          {"patient_id", "treatment_note_template_id"},
+		"treatment_note_templates": {"name"},
+	}
+}
+
+func defaultSettings() map[string]any {
+	return map[string]any{
+		"account": map[string]any{
+			"country":           "Australia",
+			"country_code":      "AU",
+			"currency_symbol":   "$",
+			"time_zone_support": false,
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"message": message})
+}
+
+func writeValidationError(w http.ResponseWriter, errors map[string]string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+		"message": "Validation failed",
+		"errors":  errors,
+	})
+}
+
+// toRecord converts any JSON serializable value to a record
+func toRecord(value any) (map[string]any, error) {
+	if record, ok := value.(map[string]any); ok {
+		return record, nil
+	}
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("value is not a JSON object: %w", err)
+	}
+	return record, nil
+}
@@ -0,0 +1,345 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikotest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	cliniko "github.com/BenKluwe/cliniko-api-client"
+	"github.com/BenKluwe/cliniko-api-client/clinikotest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func newClient(t *testing.T, srv *clinikotest.Server) *cliniko.ClinikoClient {
+	t.Helper()
+
+	client, err := srv.NewClinikoClient("clinikotest", "test@example.com")
+	if err != nil {
+		t.Fatalf("NewClinikoClient: %v", err)
+	}
+	return client
+}
+
+func seed(t *testing.T, srv *clinikotest.Server, name string, value map[string]any) string {
+	t.Helper()
+
+	id, err := srv.Seed(name, value)
+	if err != nil {
+		t.Fatalf("Seed(%q): %v", name, err)
+	}
+	return id
+}
+
+func TestPagination(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	for i := 0; i < 120; i++ {
+		seed(t, srv, "patients", map[string]any{
+			"first_name": fmt.Sprintf("Patient %d", i),
+			"last_name":  "Doe",
+		})
+	}
+
+	perPage := 50
+	it := client.IteratePatients(context.Background(), &cliniko.ListPatientsGetParams{PerPage: &perPage})
+	patients, err := it.All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(patients) != 120 {
+		t.Fatalf("got %d patients, want 120", len(patients))
+	}
+	if it.TotalEntries() != 120 {
+		t.Errorf("TotalEntries = %d, want 120", it.TotalEntries())
+	}
+	if name := *patients[119].FirstName; name != "Patient 119" {
+		t.Errorf("last patient = %q, want %q", name, "Patient 119")
+	}
+}
+
+func TestSeedExplicitId(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	seed(t, srv, "patients", map[string]any{"id": "5", "first_name": "Seeded", "last_name": "Doe"})
+	seed(t, srv, "patients", map[string]any{"id": "2", "first_name": "Low", "last_name": "Doe"})
+
+	ids := map[string]bool{"5": true, "2": true}
+	for i := 0; i < 6; i++ {
+		id := seed(t, srv, "patients", map[string]any{"first_name": "Generated", "last_name": "Doe"})
+		if ids[id] {
+			t.Fatalf("generated id %s was already taken", id)
+		}
+		ids[id] = true
+	}
+
+	record, ok := srv.Record("patients", "5")
+	if !ok || record["first_name"] != "Seeded" {
+		t.Errorf("seeded record 5 = %v, want it to be kept", record)
+	}
+	if n := len(srv.Records("patients")); n != 8 {
+		t.Errorf("stored %d patients, want 8", n)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+
+	unauthorized, err := cliniko.NewClinikoClientWithOptions(
+		"wrong-au1", "clinikotest", "test@example.com",
+		cliniko.WithBaseURL(srv.BaseURL()),
+	)
+	if err != nil {
+		t.Fatalf("NewClinikoClientWithOptions: %v", err)
+	}
+
+	_, err = cliniko.Check(unauthorized.GetSettingsGetWithResponse(context.Background()))
+	if !errors.Is(err, cliniko.ErrUnauthorized) {
+		t.Errorf("wrong API key: got %v, want ErrUnauthorized", err)
+	}
+
+	client := newClient(t, srv)
+	_, err = cliniko.Check(client.GetPatientGetWithResponse(context.Background(), "404", nil))
+	if !errors.Is(err, cliniko.ErrNotFound) {
+		t.Errorf("unknown patient: got %v, want ErrNotFound", err)
+	}
+
+	_, err = cliniko.Check(client.CreatePatientPostWithResponse(
+		context.Background(),
+		cliniko.CreatePatientPostJSONRequestBody{},
+	))
+	var validation *cliniko.ValidationErrorResponse
+	if !errors.As(err, &validation) {
+		t.Fatalf("missing names: got %v, want ValidationErrorResponse", err)
+	}
+	if _, ok := validation.FieldErrors()["first_name"]; !ok {
+		t.Errorf("FieldErrors = %v, want first_name", validation.FieldErrors())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	srv := clinikotest.NewServer(
+		clinikotest.WithRateLimit(2),
+		clinikotest.WithClock(func() time.Time { return now }),
+	)
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cliniko.Check(client.GetSettingsGetWithResponse(context.Background())); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+
+	_, err := cliniko.Check(client.GetSettingsGetWithResponse(context.Background()))
+	var apiErr *cliniko.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, cliniko.ErrRateLimited) {
+		t.Fatalf("third request: got %v, want ErrRateLimited", err)
+	}
+	if apiErr.RetryAfter != time.Minute {
+		t.Errorf("RetryAfter = %v, want 1m", apiErr.RetryAfter)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := cliniko.Check(client.GetSettingsGetWithResponse(context.Background())); err != nil {
+		t.Errorf("request after the window: %v", err)
+	}
+}
+
+func TestLinkIdFilter(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	jane := seed(t, srv, "patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	john := seed(t, srv, "patients", map[string]any{"first_name": "John", "last_name": "Doe"})
+	for _, patientId := range []string{jane, john, jane} {
+		seed(t, srv, "individual_appointments", map[string]any{
+			"patient_id": patientId,
+			"starts_at":  "2024-01-01T09:00:00Z",
+		})
+	}
+
+	appointments, err := client.IterateIndividualAppointments(
+		context.Background(),
+		&cliniko.ListIndividualAppointmentsGetParams{
+			Q: &[]string{"patient_id:=" + jane},
+		},
+	).All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(appointments) != 2 {
+		t.Errorf("got %d appointments of patient %s, want 2", len(appointments), jane)
+	}
+}
+
+func TestArchived(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	ctx := context.Background()
+
+	seed(t, srv, "patients", map[string]any{"first_name": "Jane", "last_name": "Doe"})
+	archivedId := seed(t, srv, "patients", map[string]any{"first_name": "John", "last_name": "Doe"})
+	if _, err := cliniko.Check(client.ArchivePatientPostWithResponse(ctx, archivedId)); err != nil {
+		t.Fatalf("ArchivePatientPost: %v", err)
+	}
+
+	archived := &[]string{"archived_at:*"}
+	tests := []struct {
+		name   string
+		params *cliniko.ListPatientsGetParams
+		want   int
+	}{
+		{"default", nil, 1},
+		{"archived_at:*", &cliniko.ListPatientsGetParams{Q: archived}, 2},
+	}
+	for _, tt := range tests {
+		patients, err := client.IteratePatients(ctx, tt.params).All()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(patients) != tt.want {
+			t.Errorf("%s: got %d patients, want %d", tt.name, len(patients), tt.want)
+		}
+	}
+
+	_, err := cliniko.Check(client.GetPatientGetWithResponse(ctx, archivedId, nil))
+	if !errors.Is(err, cliniko.ErrNotFound) {
+		t.Errorf("get archived patient: got %v, want ErrNotFound", err)
+	}
+
+	rsp, err := cliniko.Check(client.GetPatientGetWithResponse(ctx, archivedId, &cliniko.GetPatientGetParams{
+		Q: &[]string{"archived_at:*"},
+	}))
+	if err != nil {
+		t.Fatalf("get archived patient with archived_at:*: %v", err)
+	}
+	if rsp.JSON200.ArchivedAt == nil {
+		t.Errorf("archived_at is not set")
+	}
+}
+
+func TestAvailableTimesInBusinessTimeZone(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	businessId := seed(t, srv, "businesses", map[string]any{
+		"business_name":        "Sydney",
+		"time_zone_identifier": "Australia/Sydney",
+	})
+	practitionerId := seed(t, srv, "practitioners", map[string]any{"first_name": "Pat"})
+	appointmentTypeId := seed(t, srv, "appointment_types", map[string]any{
+		"name":                "Consult",
+		"duration_in_minutes": 30,
+	})
+
+	// daylight saving time ends on 2024-04-07, the range
+	// below is 7 days but 169 hours long
+	srv.AddAvailableTimes(businessId, practitionerId, appointmentTypeId,
+		time.Date(2024, 3, 31, 23, 30, 0, 0, sydney),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, sydney),
+		time.Date(2024, 4, 8, 23, 30, 0, 0, sydney),
+		time.Date(2024, 4, 9, 0, 0, 0, 0, sydney),
+	)
+
+	rsp, err := cliniko.Check(client.GetAllAvailableTimesGetWithResponse(
+		context.Background(), businessId, practitionerId, appointmentTypeId,
+		&cliniko.GetAllAvailableTimesGetParams{
+			From: openapi_types.Date{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+			To:   openapi_types.Date{Time: time.Date(2024, 4, 8, 0, 0, 0, 0, time.UTC)},
+		},
+	))
+	if err != nil {
+		t.Fatalf("GetAllAvailableTimesGet: %v", err)
+	}
+
+	var body struct {
+		AvailableTimes []struct {
+			AppointmentStart time.Time `json:"appointment_start"`
+		} `json:"available_times"`
+	}
+	if err := json.Unmarshal(rsp.Body, &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	want := []time.Time{
+		time.Date(2024, 4, 1, 0, 0, 0, 0, sydney),
+		time.Date(2024, 4, 8, 23, 30, 0, 0, sydney),
+	}
+	if len(body.AvailableTimes) != len(want) {
+		t.Fatalf("got %d available times, want %d", len(body.AvailableTimes), len(want))
+	}
+	for i, w := range want {
+		if got := body.AvailableTimes[i].AppointmentStart; !got.Equal(w) {
+			t.Errorf("available time %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	srv := clinikotest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+	ctx := context.Background()
+
+	first := seed(t, srv, "individual_appointments", map[string]any{
+		"practitioner_id": "1",
+		"starts_at":       "2024-01-01T09:00:00Z",
+		"ends_at":         "2024-01-01T09:30:00Z",
+	})
+	second := seed(t, srv, "individual_appointments", map[string]any{
+		"practitioner_id": "1",
+		"starts_at":       "2024-01-01T09:15:00Z",
+		"ends_at":         "2024-01-01T09:45:00Z",
+	})
+
+	conflicts := func(id string) bool {
+		t.Helper()
+		rsp, err := cliniko.Check(client.GetIndividualAppointmentConflictsGetWithResponse(ctx, id))
+		if err != nil {
+			t.Fatalf("GetIndividualAppointmentConflictsGet: %v", err)
+		}
+		var body struct {
+			Conflicts struct {
+				Exist bool `json:"exist"`
+			} `json:"conflicts"`
+		}
+		if err := json.Unmarshal(rsp.Body, &body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return body.Conflicts.Exist
+	}
+
+	if !conflicts(first) {
+		t.Errorf("overlapping appointments do not conflict")
+	}
+
+	reason := cliniko.CancelIndividualAppointmentPatchJSONBodyCancellationReason(50)
+	rsp, err := client.CancelIndividualAppointmentPatchWithResponse(ctx, second,
+		cliniko.CancelIndividualAppointmentPatchJSONRequestBody{CancellationReason: &reason})
+	if err != nil || rsp.StatusCode() != http.StatusNoContent {
+		t.Fatalf("CancelIndividualAppointmentPatch: %v %v", rsp.Status(), err)
+	}
+	if conflicts(first) {
+		t.Errorf("cancelled appointment still conflicts")
+	}
+}
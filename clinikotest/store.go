@@ -0,0 +1,657 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+package clinikotest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 100
+)
+
+// bookingCollections hold the records served under /bookings
+var bookingCollections = []string{
+	"individual_appointments",
+	"group_appointments",
+	"unavailable_blocks",
+}
+
+// cancellationReasons maps cancellation reasons
+// to their cancellation_reason_description
+var cancellationReasons = map[int]string{
+	10: "Feeling Better",
+	20: "Condition Worse",
+	30: "Sick",
+	31: "COVID-19 related",
+	40: "Away",
+	50: "Other",
+	60: "Work",
+}
+
+// unfilteredGets are the archivable collections whose get
+// endpoint takes no q[], archived records are always returned
+var unfilteredGets = map[string]bool{
+	"patient_forms":     true,
+	"product_suppliers": true,
+}
+
+// collection holds the records of one resource in insertion order
+type collection struct {
+	ids     []string
+	records map[string]map[string]any
+}
+
+// Seed stores a record in the named collection, e.g. "patients",
+// and returns its id. Fields ending in _id are turned into links
+// the same way the API does on create.
+func (s *Server) Seed(name string, value any) (string, error) {
+	record, err := toRecord(value)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(name, record), nil
+}
+
+// Record returns a copy of a stored record
+func (s *Server) Record(name string, id string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.collection(name).records[id]
+	if !ok {
+		return nil, false
+	}
+	return copyRecord(record), true
+}
+
+// Records returns copies of all records of a collection,
+// including archived and deleted ones
+func (s *Server) Records(name string) []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(name)
+	records := make([]map[string]any, 0, len(c.ids))
+	for _, id := range c.ids {
+		records = append(records, copyRecord(c.records[id]))
+	}
+	return records
+}
+
+// collection returns the named collection, creating it if
+// required, the caller must hold s.mu
+func (s *Server) collection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = &collection{records: map[string]map[string]any{}}
+		s.collections[name] = c
+	}
+	return c
+}
+
+// insert assigns an id and timestamps to record and stores it,
+// the caller must hold s.mu. A record with an id keeps it, later
+// generated ids continue after it and never reuse a stored id.
+func (s *Server) insert(name string, record map[string]any) string {
+	c := s.collection(name)
+
+	id, _ := record["id"].(string)
+	if id != "" {
+		if n, err := strconv.ParseInt(id, 10, 64); err == nil && n > s.nextId {
+			s.nextId = n
+		}
+	} else {
+		for id == "" || c.records[id] != nil {
+			s.nextId++
+			id = strconv.FormatInt(s.nextId, 10)
+		}
+	}
+
+	now := s.timestamp()
+	record["id"] = id
+	setDefault(record, "created_at", now)
+	setDefault(record, "updated_at", now)
+	setDefault(record, "archived_at", nil)
+	s.linkify(record)
+	record["links"] = map[string]any{"self": s.BaseURL() + "/" + name + "/" + id}
+
+	if _, ok := c.records[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.records[id] = record
+	return id
+}
+
+// linkify replaces <name>_id fields with the nested
+// {"links": {"self": ...}} objects returned by the API
+func (s *Server) linkify(record map[string]any) {
+	for key, value := range record {
+		if !strings.HasSuffix(key, "_id") || key == "old_reference_id" {
+			continue
+		}
+
+		id, ok := value.(string)
+		if !ok || id == "" {
+			continue
+		}
+
+		name := strings.TrimSuffix(key, "_id")
+		record[name] = map[string]any{
+			"links": map[string]any{"self": s.BaseURL() + "/" + plural(name) + "/" + id},
+		}
+		delete(record, key)
+	}
+}
+
+// find returns a record that has not been deleted,
+// the caller must hold s.mu
+func (s *Server) find(name string, id string) (map[string]any, bool) {
+	record, ok := s.collection(name).records[id]
+	if !ok || record["deleted_at"] != nil {
+		return nil, false
+	}
+	return record, true
+}
+
+// belongsTo reports whether record links to the
+// parent record, e.g. an invoice to /patients/1
+func (s *Server) belongsTo(record map[string]any, parent string, id string) bool {
+	names := []string{singular(parent)}
+	switch parent {
+	case "individual_appointments", "group_appointments", "appointments":
+		names = append(names, "booking", "appointment")
+	}
+
+	for _, name := range names {
+		if linkId(record[name]) == id {
+			return true
+		}
+		if ids, ok := record[name+"_ids"].([]any); ok {
+			for _, value := range ids {
+				if value == id {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// serveGet writes a single record. Like lists, archived records
+// are only returned when a q[] filter on archived_at is given,
+// except for collections whose get endpoint accepts no filters.
+func (s *Server) serveGet(w http.ResponseWriter, r *http.Request, name string, id string) {
+	filters, err := parseFilters(r.URL.Query()["q[]"])
+	if err != nil {
+		writeValidationError(w, map[string]string{"q": err.Error()})
+		return
+	}
+
+	record, ok := s.find(name, id)
+	if !ok || (!unfilteredGets[name] && !filters.match(record)) {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) serveCreate(w http.ResponseWriter, r *http.Request, name string) {
+	record, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	invalid := map[string]string{}
+	for _, field := range s.required[name] {
+		if value, ok := record[field]; !ok || value == nil || value == "" {
+			invalid[field] = "can't be blank"
+		}
+	}
+	if len(invalid) > 0 {
+		writeValidationError(w, invalid)
+		return
+	}
+
+	delete(record, "id")
+	s.applyDefaults(name, record)
+	id := s.insert(name, record)
+	writeJSON(w, http.StatusCreated, s.collection(name).records[id])
+}
+
+// applyDefaults fills in fields the API derives on create
+func (s *Server) applyDefaults(name string, record map[string]any) {
+	switch name {
+	case "individual_appointments", "group_appointments":
+		setDefault(record, "cancelled_at", nil)
+		setDefault(record, "deleted_at", nil)
+		if _, ok := record["ends_at"]; !ok {
+			if startsAt, ok := parseTime(record["starts_at"]); ok {
+				duration := s.appointmentDuration(record["appointment_type_id"])
+				record["ends_at"] = startsAt.Add(duration).UTC().Format(time.RFC3339)
+			}
+		}
+	case "attendees":
+		setDefault(record, "cancelled_at", nil)
+		setDefault(record, "deleted_at", nil)
+	case "practitioners", "users":
+		setDefault(record, "active", true)
+	}
+}
+
+// appointmentDuration returns the duration of the given
+// appointment type, 30 minutes if it is unknown
+func (s *Server) appointmentDuration(appointmentTypeId any) time.Duration {
+	id, _ := appointmentTypeId.(string)
+	if id == "" {
+		id = linkId(appointmentTypeId)
+	}
+
+	if appointmentType, ok := s.find("appointment_types", id); ok {
+		if minutes, ok := appointmentType["duration_in_minutes"].(float64); ok {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return 30 * time.Minute
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, name string, id string) {
+	record, ok := s.find(name, id)
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	update, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	s.linkify(update)
+	for _, key := range []string{"id", "links", "created_at"} {
+		delete(update, key)
+	}
+	for key, value := range update {
+		record[key] = value
+	}
+	record["updated_at"] = s.timestamp()
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) serveArchive(w http.ResponseWriter, name string, id string, archive bool) {
+	record, ok := s.find(name, id)
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	now := s.timestamp()
+	if archive {
+		record["archived_at"] = now
+	} else {
+		record["archived_at"] = nil
+	}
+	record["updated_at"] = now
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveCancel(w http.ResponseWriter, r *http.Request, name string, id string) {
+	record, ok := s.find(name, id)
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	reason, _ := body["cancellation_reason"].(float64)
+	description, ok := cancellationReasons[int(reason)]
+	if !ok {
+		writeValidationError(w, map[string]string{
+			"cancellation_reason": "is not included in the list",
+		})
+		return
+	}
+
+	now := s.timestamp()
+	record["cancelled_at"] = now
+	record["cancellation_reason"] = int(reason)
+	record["cancellation_reason_description"] = description
+	record["cancellation_note"] = body["cancellation_note"]
+	record["updated_at"] = now
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveConflicts reports whether the booking overlaps any other
+// active booking of the same practitioner
+func (s *Server) serveConflicts(w http.ResponseWriter, name string, id string) {
+	record, ok := s.find(name, id)
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	exist := false
+	for _, other := range s.activeBookings(linkId(record["practitioner"])) {
+		if other["id"] != id && overlaps(record, other) {
+			exist = true
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"conflicts": map[string]any{"exist": exist},
+	})
+}
+
+// activeBookings returns all bookings of a practitioner that
+// are neither cancelled, archived nor deleted
+func (s *Server) activeBookings(practitionerId string) []map[string]any {
+	var bookings []map[string]any
+	for _, name := range bookingCollections {
+		c := s.collection(name)
+		for _, id := range c.ids {
+			record := c.records[id]
+			if record["deleted_at"] != nil ||
+				record["archived_at"] != nil ||
+				record["cancelled_at"] != nil ||
+				linkId(record["practitioner"]) != practitionerId {
+				continue
+			}
+			bookings = append(bookings, record)
+		}
+	}
+	return bookings
+}
+
+func (s *Server) serveBookings(w http.ResponseWriter, r *http.Request, include func(map[string]any) bool) {
+	var records []map[string]any
+	for _, name := range bookingCollections {
+		c := s.collection(name)
+		for _, id := range c.ids {
+			if include == nil || include(c.records[id]) {
+				records = append(records, c.records[id])
+			}
+		}
+	}
+	s.writeList(w, r, "bookings", records)
+}
+
+func (s *Server) serveBooking(w http.ResponseWriter, r *http.Request, id string) {
+	for _, name := range bookingCollections {
+		if _, ok := s.find(name, id); ok {
+			s.serveGet(w, r, name, id)
+			return
+		}
+	}
+	writeMessage(w, http.StatusNotFound, "Not Found")
+}
+
+func (s *Server) serveReferralSource(w http.ResponseWriter, r *http.Request, patientId string) {
+	if _, ok := s.find("patients", patientId); !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	c := s.collection("referral_sources")
+	var existing map[string]any
+	for _, id := range c.ids {
+		if linkId(c.records[id]["patient"]) == patientId {
+			existing = c.records[id]
+			break
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if existing == nil {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPatch:
+		update, ok := decodeBody(w, r)
+		if !ok {
+			return
+		}
+		if existing == nil {
+			update["patient_id"] = patientId
+			id := s.insert("referral_sources", update)
+			writeJSON(w, http.StatusOK, c.records[id])
+			return
+		}
+		s.linkify(update)
+		for key, value := range update {
+			existing[key] = value
+		}
+		existing["updated_at"] = s.timestamp()
+		writeJSON(w, http.StatusOK, existing)
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// serveList writes a page of the records of a collection
+// that match include and the q[] filters of the request
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, name string, include func(map[string]any) bool) {
+	c := s.collection(name)
+	records := make([]map[string]any, 0, len(c.ids))
+	for _, id := range c.ids {
+		if include == nil || include(c.records[id]) {
+			records = append(records, c.records[id])
+		}
+	}
+	s.writeList(w, r, name, records)
+}
+
+// writeList filters, sorts and paginates records
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, key string, records []map[string]any) {
+	query := r.URL.Query()
+
+	filters, err := parseFilters(query["q[]"])
+	if err != nil {
+		writeValidationError(w, map[string]string{"q": err.Error()})
+		return
+	}
+
+	matching := make([]map[string]any, 0, len(records))
+	for _, record := range records {
+		if filters.match(record) {
+			matching = append(matching, record)
+		}
+	}
+
+	sortRecords(matching, query.Get("sort"), query.Get("order"))
+
+	page, perPage, ok := pageParams(w, query)
+	if !ok {
+		return
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+	if start > len(matching) {
+		start = len(matching)
+	}
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	links := map[string]any{"self": s.pageURL(r, page)}
+	if end < len(matching) {
+		links["next"] = s.pageURL(r, page+1)
+	}
+	if page > 1 {
+		links["previous"] = s.pageURL(r, page-1)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		key:             matching[start:end],
+		"total_entries": len(matching),
+		"links":         links,
+	})
+}
+
+// pageParams reads and validates page and per_page
+func pageParams(w http.ResponseWriter, query url.Values) (int, int, bool) {
+	page, perPage := 1, defaultPerPage
+
+	if value := query.Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeValidationError(w, map[string]string{"page": "must be greater than 0"})
+			return 0, 0, false
+		}
+		page = parsed
+	}
+
+	if value := query.Get("per_page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPerPage {
+			writeValidationError(w, map[string]string{"per_page": "must be between 1 and 100"})
+			return 0, 0, false
+		}
+		perPage = parsed
+	}
+
+	return page, perPage, true
+}
+
+// pageURL returns the absolute URL of the request with page replaced
+func (s *Server) pageURL(r *http.Request, page int) string {
+	query := r.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	return s.URL + r.URL.Path + "?" + query.Encode()
+}
+
+// sortRecords orders records by the comma separated sort
+// fields, defaulting to the order they were created in
+func sortRecords(records []map[string]any, fields string, order string) {
+	keys := []string{"id"}
+	if fields != "" {
+		keys = strings.Split(fields, ",")
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, key := range keys {
+			cmp := compareValues(records[i][strings.TrimSpace(key)], records[j][strings.TrimSpace(key)])
+			if cmp == 0 {
+				continue
+			}
+			if order == "desc" {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// decodeBody reads the JSON object of a request body, an
+// empty body is decoded as an empty object
+func decodeBody(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	record := map[string]any{}
+	if r.Body == nil || r.ContentLength == 0 {
+		return record, true
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&record)
+	if errors.Is(err, io.EOF) {
+		return record, true
+	}
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return nil, false
+	}
+	return record, true
+}
+
+// linkId returns the id at the end of a nested
+// {"links": {"self": ...}} object
+func linkId(value any) string {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return ""
+	}
+	links, ok := object["links"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	self, ok := links["self"].(string)
+	if !ok {
+		return ""
+	}
+	return self[strings.LastIndex(self, "/")+1:]
+}
+
+// overlaps reports whether the time ranges of two bookings overlap
+func overlaps(a map[string]any, b map[string]any) bool {
+	aStart, ok1 := parseTime(a["starts_at"])
+	aEnd, ok2 := parseTime(a["ends_at"])
+	bStart, ok3 := parseTime(b["starts_at"])
+	bEnd, ok4 := parseTime(b["ends_at"])
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return false
+	}
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+func parseTime(value any) (time.Time, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, text)
+	return parsed, err == nil
+}
+
+func setDefault(record map[string]any, key string, value any) {
+	if _, ok := record[key]; !ok {
+		record[key] = value
+	}
+}
+
+func copyRecord(record map[string]any) map[string]any {
+	body, _ := json.Marshal(record)
+	var copied map[string]any
+	_ = json.Unmarshal(body, &copied)
+	return copied
+}
+
+// plural returns the collection name of a linked resource
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"):
+		return name + "es"
+	case strings.HasSuffix(name, "y"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
+// singular returns the field name that links to a collection
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	}
+	return strings.TrimSuffix(name, "s")
+}
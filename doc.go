@@ -16,6 +16,16 @@ To get started create a new ClinikoClient:
 Where token is the the token copied directly from the Cliniko API. The shard is deduced from the token.
 The vendor name and email will be passed in the User-Agent field with each outgoing request.
 
+Options can override the shard, the base URL or the Doer used for requests:
+
+	client, err := NewClinikoClientWithOptions(
+		"token",
+		"vendor",
+		"vendor email",
+		WithBaseURL("https://proxy.example.com/v1"),
+		WithHTTPDoer(NewRateLimitedDoer(nil, DefaultRequestsPerMinute)),
+	)
+
 Use any *WithResponse function to execute a query and get a parsed response:
 
 	page, perPage, sort, order :=
@@ -34,6 +44,19 @@ Use any *WithResponse function to execute a query and get a parsed response:
 
 	log.Println(appointments.JSON200.TotalEntries)
 
+Every list endpoint has an Iterate* counterpart that follows the pagination
+links and yields the items one by one:
+
+	it := client.IterateAppointmentTypes(
+		context.TODO(),
+		&ListAppointmentTypesGetParams{PerPage: &perPage})
+	for it.Next() {
+		log.Println(*it.Item().Name)
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+
 One special case exists for creating an attachment as this is a multi-step process:
 
 	contents := []byte{0}
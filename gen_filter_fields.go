@@ -0,0 +1,199 @@
+// Use of this source code is governed by the LGPL 2.1
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// gen_filter_fields generates cliniko_filter_fields.go from the
+// q[] parameters of the list endpoints in cliniko.json. Cliniko
+// documents the filterable fields only in the description of the
+// parameter, as a table of field names and formats.
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	specFile   = "cliniko.json"
+	outputFile = "cliniko_filter_fields.go"
+)
+
+// filterRow matches a row of the table of available filters,
+// e.g. "| id | [int64](/developer-portal/#numeric-filter-operators) |"
+var filterRow = regexp.MustCompile(`(?m)^\| (\w+) \| \[([\w-]+)\]`)
+
+// filterKinds maps the formats of the table to the filter
+// types and FilterKind constants of cliniko_filters.go
+var filterKinds = map[string]string{
+	"int64":     "Numeric",
+	"integer":   "Numeric",
+	"decimal":   "Decimal",
+	"date":      "Date",
+	"date-time": "DateTime",
+	"string":    "String",
+	"boolean":   "Boolean",
+	"array":     "Array",
+}
+
+type parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description"`
+}
+
+type operation struct {
+	OperationId string      `json:"operationId"`
+	Parameters  []parameter `json:"parameters"`
+}
+
+type field struct {
+	name string
+	kind string
+}
+
+type filterable struct {
+	operation string
+	fields    []field
+}
+
+func main() {
+	spec, err := os.ReadFile(specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	operations, err := filterableOperations(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := format.Source(render(operations))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(outputFile, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// filterableOperations returns the operations with a q[] parameter
+// ordered by path and method, the fields in the order documented
+func filterableOperations(spec []byte) ([]filterable, error) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var operations []filterable
+	for _, path := range sortedKeys(doc.Paths) {
+		item := doc.Paths[path]
+		for _, method := range sortedKeys(item) {
+			if method == "parameters" {
+				continue
+			}
+
+			var op operation
+			if err := json.Unmarshal(item[method], &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+
+			for _, param := range op.Parameters {
+				if param.In != "query" || param.Name != "q[]" {
+					continue
+				}
+
+				fields, err := parseFields(param.Description)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op.OperationId, err)
+				}
+				operations = append(operations, filterable{
+					operation: camelCase(op.OperationId, "-"),
+					fields:    fields,
+				})
+			}
+		}
+	}
+	return operations, nil
+}
+
+// parseFields reads the table of available filters of a description
+func parseFields(description string) ([]field, error) {
+	var fields []field
+	for _, row := range filterRow.FindAllStringSubmatch(description, -1) {
+		kind, ok := filterKinds[row[2]]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter format %q of %s", row[2], row[1])
+		}
+		fields = append(fields, field{name: row[1], kind: kind})
+	}
+	return fields, nil
+}
+
+func render(operations []filterable) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_filter_fields.go from cliniko.json; DO NOT EDIT.\n\n")
+	b.WriteString("// Use of this source code is governed by the LGPL 2.1\n")
+	b.WriteString("// license that can be found in the LICENSE file.\n\n")
+	b.WriteString("package cliniko\n")
+
+	for _, op := range operations {
+		params := op.operation + "Params"
+		fmt.Fprintf(&b, "\n// %sFilters lists the fields %s\n", op.operation, op.operation)
+		b.WriteString("// can be filtered on with the q[] parameter\n")
+		fmt.Fprintf(&b, "var %sFilters = struct {\n", op.operation)
+		for _, f := range op.fields {
+			fmt.Fprintf(&b, "%s %sFilter[%s]\n", camelCase(f.name, "_"), f.kind, params)
+		}
+		b.WriteString("}{\n")
+		for _, f := range op.fields {
+			fmt.Fprintf(&b, "%s: %sFilter[%s]{%q},\n", camelCase(f.name, "_"), f.kind, params, f.name)
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString("\n// filterFields lists the filterable fields of each operation\n")
+	b.WriteString("var filterFields = map[string]map[string]FilterKind{\n")
+	for _, op := range operations {
+		fmt.Fprintf(&b, "%q: {\n", op.operation)
+		for _, f := range op.fields {
+			fmt.Fprintf(&b, "%q: FilterKind%s,\n", f.name, f.kind)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// camelCase joins the parts of s separated by sep with
+// their first letters upper cased, e.g. created_at to CreatedAt
+func camelCase(s string, sep string) string {
+	parts := strings.Split(s, sep)
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,6 +1,6 @@
 module github.com/BenKluwe/cliniko-api-client
 
-go 1.18
+go 1.21
 
 require github.com/oapi-codegen/runtime v1.0.0
 
@@ -4,15 +4,16 @@
 package cliniko
 
 const (
-	AttendeeCancelCancellationReasonFeelingBetter  = 10
-	AttendeeCancelCancellationReasonConditionWorse = 20
-	AttendeeCancelCancellationReasonSick           = 30
-	AttendeeCancelCancellationReasonCOVID19Related = 31
-	AttendeeCancelCancellationReasonAway           = 40
-	AttendeeCancelCancellationReasonOther          = 50
-	AttendeeCancelCancellationReasonWork           = 60
+	AttendeeCancelCancellationReasonFeelingBetter  AttendeeCancelCancellationReason = 10
+	AttendeeCancelCancellationReasonConditionWorse AttendeeCancelCancellationReason = 20
+	AttendeeCancelCancellationReasonSick           AttendeeCancelCancellationReason = 30
+	AttendeeCancelCancellationReasonCOVID19Related AttendeeCancelCancellationReason = 31
+	AttendeeCancelCancellationReasonAway           AttendeeCancelCancellationReason = 40
+	AttendeeCancelCancellationReasonOther          AttendeeCancelCancellationReason = 50
+	AttendeeCancelCancellationReasonWork           AttendeeCancelCancellationReason = 60
 )
 
+// AttendeeCancelCancellationReason is the reason an attendee is cancelled for
 type AttendeeCancelCancellationReason int
 
 const (
@@ -26,15 +27,17 @@ const (
 type ContactPhoneNumbersPhoneType string
 
 const (
-	IndividualAppointmentCancelCancellationReasonFeelingBetter  = 10
-	IndividualAppointmentCancelCancellationReasonConditionWorse = 20
-	IndividualAppointmentCancelCancellationReasonSick           = 30
-	IndividualAppointmentCancelCancellationReasonCOVID19Related = 31
-	IndividualAppointmentCancelCancellationReasonAway           = 40
-	IndividualAppointmentCancelCancellationReasonOther          = 50
-	IndividualAppointmentCancelCancellationReasonWork           = 60
+	IndividualAppointmentCancelCancellationReasonFeelingBetter  IndividualAppointmentCancelCancellationReason = 10
+	IndividualAppointmentCancelCancellationReasonConditionWorse IndividualAppointmentCancelCancellationReason = 20
+	IndividualAppointmentCancelCancellationReasonSick           IndividualAppointmentCancelCancellationReason = 30
+	IndividualAppointmentCancelCancellationReasonCOVID19Related IndividualAppointmentCancelCancellationReason = 31
+	IndividualAppointmentCancelCancellationReasonAway           IndividualAppointmentCancelCancellationReason = 40
+	IndividualAppointmentCancelCancellationReasonOther          IndividualAppointmentCancelCancellationReason = 50
+	IndividualAppointmentCancelCancellationReasonWork           IndividualAppointmentCancelCancellationReason = 60
 )
 
+// IndividualAppointmentCancelCancellationReason is the reason
+// an individual appointment is cancelled for
 type IndividualAppointmentCancelCancellationReason int
 
 const (
@@ -6413,7 +6413,7 @@ type ListPatientsGetParams struct {
 	// | preferred_first_name | [string](/developer-portal/#string-filter-operators) |
 	Q *[]string `form:"q[],omitempty" json:"q[],omitempty"`
 
-	Search *string `form:"search,omitempty" json:"search,omitempty`
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
 }
 
 // ListPatientsGetParamsOrder defines parameters for ListPatientsGet.